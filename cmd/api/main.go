@@ -0,0 +1,17 @@
+package main
+
+import (
+	"log"
+
+	"api/internal"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	if err := internal.Run(internal.Serve); err != nil {
+		log.Fatal(err)
+	}
+}
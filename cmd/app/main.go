@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"api/internal"
+	"api/internal/updater"
 
 	"github.com/joho/godotenv"
 )
@@ -11,7 +12,7 @@ import (
 func main() {
 	_ = godotenv.Load()
 
-	if err := internal.Bootstrap(); err != nil {
+	if err := internal.Bootstrap(updater.Listen); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"api/internal"
+	"api/internal/updater"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/joho/godotenv"
+)
+
+const usage = `usage: updater <command> [flags]
+
+commands:
+  update        fetch, annotate and store today's wallpapers once
+  listen        run an update for every message published to the updater topic
+  dryrun        report what an update would change without writing anything
+  reannotate    refresh tags, colors and landmarks of stored wallpapers
+  attributions  parse photographers and agencies of stored wallpapers again and recount them
+  mirror        copy images of stored wallpapers that aren't mirrored yet into the blob store
+  placeholders  render the BlurHash and preview of stored wallpapers without them
+  hashes        compute the perceptual hash of stored wallpapers without one, to detect near-duplicates of them
+  measure       record the dimensions, size and quality of stored wallpapers' resolution variants
+`
+
+func main() {
+	_ = godotenv.Load()
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var run func(ctx context.Context, db *firestore.Client, args []string) error
+	switch os.Args[1] {
+	case "update":
+		run = update
+	case "listen":
+		run = listen
+	case "dryrun":
+		run = dryRun
+	case "reannotate":
+		run = reannotate
+	case "attributions":
+		run = attributions
+	case "mirror":
+		run = mirror
+	case "placeholders":
+		run = placeholders
+	case "hashes":
+		run = hashes
+	case "measure":
+		run = measure
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	err := internal.Run(func(ctx context.Context, db *firestore.Client) error {
+		return run(ctx, db, os.Args[2:])
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func update(ctx context.Context, db *firestore.Client, _ []string) error {
+	return updater.Update(ctx, db)
+}
+
+func listen(ctx context.Context, db *firestore.Client, _ []string) error {
+	return updater.Listen(ctx, db)
+}
+
+func dryRun(ctx context.Context, db *firestore.Client, args []string) error {
+	fs := flag.NewFlagSet("dryrun", flag.ExitOnError)
+	annotate := fs.Bool("annotate", false, "translate and annotate new images")
+	markets := fs.String("markets", "", "comma-separated markets to fetch (default all)")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	_ = fs.Parse(args)
+
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	opts := updater.DryRunOptions{Annotate: *annotate}
+	if *markets != "" {
+		opts.Markets = strings.Split(*markets, ",")
+	}
+
+	report, err := u.DryRun(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return report.WriteText(os.Stdout)
+}
+
+func reannotate(ctx context.Context, db *firestore.Client, args []string) error {
+	fs := flag.NewFlagSet("reannotate", flag.ExitOnError)
+	from := fs.Int("from", 0, "first date to re-annotate, e.g. 20200101")
+	to := fs.Int("to", 0, "last date to re-annotate, e.g. 20201231")
+	missing := fs.Bool("missing", false, "only re-annotate wallpapers without tags or colors")
+	limit := fs.Int("limit", 0, "maximum number of wallpapers to re-annotate")
+	interval := fs.Duration("interval", time.Second, "minimum time between annotations")
+	checkpoint := fs.String("checkpoint", "reannotate", "name of the saved position to resume from, empty to start over")
+	_ = fs.Parse(args)
+
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return u.Locked(ctx, func(ctx context.Context, u *updater.Updater) error {
+		_, err := u.Reannotate(ctx, updater.ReannotateOptions{
+			FromDate:    *from,
+			ToDate:      *to,
+			MissingOnly: *missing,
+			Limit:       *limit,
+			Interval:    *interval,
+			Checkpoint:  *checkpoint,
+		})
+		return err
+	})
+}
+
+func attributions(ctx context.Context, db *firestore.Client, _ []string) error {
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return u.Locked(ctx, func(ctx context.Context, u *updater.Updater) error {
+		_, err := u.RebuildAttributions(ctx)
+		return err
+	})
+}
+
+func mirror(ctx context.Context, db *firestore.Client, _ []string) error {
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return u.Locked(ctx, func(ctx context.Context, u *updater.Updater) error {
+		_, err := u.MirrorAll(ctx)
+		return err
+	})
+}
+
+func placeholders(ctx context.Context, db *firestore.Client, args []string) error {
+	fs := flag.NewFlagSet("placeholders", flag.ExitOnError)
+	force := fs.Bool("force", false, "render placeholders of all wallpapers again")
+	_ = fs.Parse(args)
+
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return u.Locked(ctx, func(ctx context.Context, u *updater.Updater) error {
+		_, err := u.BackfillPlaceholders(ctx, *force)
+		return err
+	})
+}
+
+func hashes(ctx context.Context, db *firestore.Client, args []string) error {
+	fs := flag.NewFlagSet("hashes", flag.ExitOnError)
+	force := fs.Bool("force", false, "hash all wallpapers again")
+	_ = fs.Parse(args)
+
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return u.Locked(ctx, func(ctx context.Context, u *updater.Updater) error {
+		_, err := u.BackfillHashes(ctx, *force)
+		return err
+	})
+}
+
+func measure(ctx context.Context, db *firestore.Client, _ []string) error {
+	u, err := updater.New(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return u.Locked(ctx, func(ctx context.Context, u *updater.Updater) error {
+		_, err := u.MeasureAll(ctx)
+		return err
+	})
+}
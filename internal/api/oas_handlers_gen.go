@@ -33,20 +33,1262 @@ func (c *codeRecorder) Unwrap() http.ResponseWriter {
 	return c.ResponseWriter
 }
 
+// handleGetJobRequest handles getJob operation.
+//
+// Returns an updater run.
+//
+// GET /admin/jobs/{id}
+func (s *Server) handleGetJobRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getJob"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/admin/jobs/{id}"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetJobOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetJobOperation,
+			ID:   "getJob",
+		}
+	)
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			sctx, ok, err := s.securityAdminAuth(ctx, GetJobOperation, r)
+			if err != nil {
+				err = &ogenerrors.SecurityError{
+					OperationContext: opErrContext,
+					Security:         "AdminAuth",
+					Err:              err,
+				}
+				defer recordError("Security:AdminAuth", err)
+				s.cfg.ErrorHandler(ctx, w, r, err)
+				return
+			}
+			if ok {
+				satisfied[0] |= 1 << 0
+				ctx = sctx
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			err = &ogenerrors.SecurityError{
+				OperationContext: opErrContext,
+				Err:              ogenerrors.ErrSecurityRequirementIsNotSatisfied,
+			}
+			defer recordError("Security", err)
+			s.cfg.ErrorHandler(ctx, w, r, err)
+			return
+		}
+	}
+	params, err := decodeGetJobParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	var rawBody []byte
+
+	var response GetJobRes
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetJobOperation,
+			OperationSummary: "Returns an updater run",
+			OperationID:      "getJob",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params: middleware.Parameters{
+				{
+					Name: "id",
+					In:   "path",
+				}: params.ID,
+			},
+			Raw: r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = GetJobParams
+			Response = GetJobRes
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			unpackGetJobParams,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetJob(ctx, params)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetJob(ctx, params)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetJobResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetJobsRequest handles getJobs operation.
+//
+// Returns updater runs, latest first.
+//
+// GET /admin/jobs
+func (s *Server) handleGetJobsRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getJobs"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/admin/jobs"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetJobsOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetJobsOperation,
+			ID:   "getJobs",
+		}
+	)
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			sctx, ok, err := s.securityAdminAuth(ctx, GetJobsOperation, r)
+			if err != nil {
+				err = &ogenerrors.SecurityError{
+					OperationContext: opErrContext,
+					Security:         "AdminAuth",
+					Err:              err,
+				}
+				defer recordError("Security:AdminAuth", err)
+				s.cfg.ErrorHandler(ctx, w, r, err)
+				return
+			}
+			if ok {
+				satisfied[0] |= 1 << 0
+				ctx = sctx
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			err = &ogenerrors.SecurityError{
+				OperationContext: opErrContext,
+				Err:              ogenerrors.ErrSecurityRequirementIsNotSatisfied,
+			}
+			defer recordError("Security", err)
+			s.cfg.ErrorHandler(ctx, w, r, err)
+			return
+		}
+	}
+	params, err := decodeGetJobsParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	var rawBody []byte
+
+	var response []Job
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetJobsOperation,
+			OperationSummary: "Returns updater runs, latest first",
+			OperationID:      "getJobs",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params: middleware.Parameters{
+				{
+					Name: "limit",
+					In:   "query",
+				}: params.Limit,
+				{
+					Name: "startAfterID",
+					In:   "query",
+				}: params.StartAfterID,
+			},
+			Raw: r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = GetJobsParams
+			Response = []Job
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			unpackGetJobsParams,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetJobs(ctx, params)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetJobs(ctx, params)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetJobsResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetPhotographersRequest handles getPhotographers operation.
+//
+// Returns photographers, most credited first.
+//
+// GET /photographers
+func (s *Server) handleGetPhotographersRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getPhotographers"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/photographers"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetPhotographersOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetPhotographersOperation,
+			ID:   "getPhotographers",
+		}
+	)
+	params, err := decodeGetPhotographersParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	var rawBody []byte
+
+	var response []Photographer
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetPhotographersOperation,
+			OperationSummary: "Returns photographers, most credited first",
+			OperationID:      "getPhotographers",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params: middleware.Parameters{
+				{
+					Name: "limit",
+					In:   "query",
+				}: params.Limit,
+			},
+			Raw: r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = GetPhotographersParams
+			Response = []Photographer
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			unpackGetPhotographersParams,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetPhotographers(ctx, params)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetPhotographers(ctx, params)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetPhotographersResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
 // handleGetRootRequest handles getRoot operation.
 //
-// GET /
-func (s *Server) handleGetRootRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+// GET /
+func (s *Server) handleGetRootRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getRoot"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetRootOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err error
+	)
+
+	var rawBody []byte
+
+	var response *GetRootOK
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetRootOperation,
+			OperationSummary: "",
+			OperationID:      "getRoot",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params:           middleware.Parameters{},
+			Raw:              r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = struct{}
+			Response = *GetRootOK
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			nil,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				err = s.h.GetRoot(ctx)
+				return response, err
+			},
+		)
+	} else {
+		err = s.h.GetRoot(ctx)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetRootResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetWallpaperRequest handles getWallpaper operation.
+//
+// Returns a wallpaper with the given ID.
+//
+// GET /wallpapers/{id}
+func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getWallpaper"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/wallpapers/{id}"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpaperOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetWallpaperOperation,
+			ID:   "getWallpaper",
+		}
+	)
+	params, err := decodeGetWallpaperParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	var rawBody []byte
+
+	var response GetWallpaperRes
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetWallpaperOperation,
+			OperationSummary: "Returns a wallpaper with the given ID",
+			OperationID:      "getWallpaper",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params: middleware.Parameters{
+				{
+					Name: "id",
+					In:   "path",
+				}: params.ID,
+				{
+					Name: "lang",
+					In:   "query",
+				}: params.Lang,
+				{
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
+			},
+			Raw: r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = GetWallpaperParams
+			Response = GetWallpaperRes
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			unpackGetWallpaperParams,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetWallpaper(ctx, params)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetWallpaper(ctx, params)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetWallpaperResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetWallpaperImageRequest handles getWallpaperImage operation.
+//
+// Without w or h the source size is kept, and with one of them the other follows the aspect ratio.
+//
+// GET /wallpapers/{id}/image
+func (s *Server) handleGetWallpaperImageRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getWallpaperImage"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/wallpapers/{id}/image"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpaperImageOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetWallpaperImageOperation,
+			ID:   "getWallpaperImage",
+		}
+	)
+	params, err := decodeGetWallpaperImageParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	var rawBody []byte
+
+	var response GetWallpaperImageRes
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetWallpaperImageOperation,
+			OperationSummary: "Returns a wallpaper resized and converted",
+			OperationID:      "getWallpaperImage",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params: middleware.Parameters{
+				{
+					Name: "id",
+					In:   "path",
+				}: params.ID,
+				{
+					Name: "w",
+					In:   "query",
+				}: params.W,
+				{
+					Name: "h",
+					In:   "query",
+				}: params.H,
+				{
+					Name: "fit",
+					In:   "query",
+				}: params.Fit,
+				{
+					Name: "format",
+					In:   "query",
+				}: params.Format,
+			},
+			Raw: r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = GetWallpaperImageParams
+			Response = GetWallpaperImageRes
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			unpackGetWallpaperImageParams,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetWallpaperImage(ctx, params)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetWallpaperImage(ctx, params)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetWallpaperImageResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetWallpaperTagsRequest handles getWallpaperTags operation.
+//
+// Returns a list of tags.
+//
+// GET /wallpapers/tags
+func (s *Server) handleGetWallpaperTagsRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getWallpaperTags"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/wallpapers/tags"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpaperTagsOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err error
+	)
+
+	var rawBody []byte
+
+	var response GetWallpaperTagsRes
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetWallpaperTagsOperation,
+			OperationSummary: "Returns a list of tags",
+			OperationID:      "getWallpaperTags",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params:           middleware.Parameters{},
+			Raw:              r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = struct{}
+			Response = GetWallpaperTagsRes
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			nil,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetWallpaperTags(ctx)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetWallpaperTags(ctx)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetWallpaperTagsResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetWallpapersRequest handles getWallpapers operation.
+//
+// Returns a list of wallpapers.
+//
+// GET /wallpapers
+func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+	statusWriter := &codeRecorder{ResponseWriter: w}
+	w = statusWriter
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getWallpapers"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/wallpapers"),
+	}
+
+	// Start a span for this request.
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersOperation,
+		trace.WithAttributes(otelAttrs...),
+		serverSpanKind,
+	)
+	defer span.End()
+
+	// Add Labeler to context.
+	labeler := &Labeler{attrs: otelAttrs}
+	ctx = contextWithLabeler(ctx, labeler)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		elapsedDuration := time.Since(startTime)
+
+		attrSet := labeler.AttributeSet()
+		attrs := attrSet.ToSlice()
+		code := statusWriter.status
+		if code != 0 {
+			codeAttr := semconv.HTTPResponseStatusCode(code)
+			attrs = append(attrs, codeAttr)
+			span.SetAttributes(codeAttr)
+		}
+		attrOpt := metric.WithAttributes(attrs...)
+
+		// Increment request counter.
+		s.requests.Add(ctx, 1, attrOpt)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		s.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), attrOpt)
+	}()
+
+	var (
+		recordError = func(stage string, err error) {
+			span.RecordError(err)
+
+			// https://opentelemetry.io/docs/specs/semconv/http/http-spans/#status
+			// Span Status MUST be left unset if HTTP status code was in the 1xx, 2xx or 3xx ranges,
+			// unless there was another error (e.g., network error receiving the response body; or 3xx codes with
+			// max redirects exceeded), in which case status MUST be set to Error.
+			code := statusWriter.status
+			if code < 100 || code >= 500 {
+				span.SetStatus(codes.Error, stage)
+			}
+
+			attrSet := labeler.AttributeSet()
+			attrs := attrSet.ToSlice()
+			if code != 0 {
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(code))
+			}
+
+			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetWallpapersOperation,
+			ID:   "getWallpapers",
+		}
+	)
+	params, err := decodeGetWallpapersParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	var rawBody []byte
+
+	var response GetWallpapersRes
+	if m := s.cfg.Middleware; m != nil {
+		mreq := middleware.Request{
+			Context:          ctx,
+			OperationName:    GetWallpapersOperation,
+			OperationSummary: "Returns a list of wallpapers",
+			OperationID:      "getWallpapers",
+			Body:             nil,
+			RawBody:          rawBody,
+			Params: middleware.Parameters{
+				{
+					Name: "limit",
+					In:   "query",
+				}: params.Limit,
+				{
+					Name: "startAfterDate",
+					In:   "query",
+				}: params.StartAfterDate,
+				{
+					Name: "startAfterID",
+					In:   "query",
+				}: params.StartAfterID,
+				{
+					Name: "prev",
+					In:   "query",
+				}: params.Prev,
+				{
+					Name: "market",
+					In:   "query",
+				}: params.Market,
+				{
+					Name: "agency",
+					In:   "query",
+				}: params.Agency,
+				{
+					Name: "orientation",
+					In:   "query",
+				}: params.Orientation,
+				{
+					Name: "safe",
+					In:   "query",
+				}: params.Safe,
+				{
+					Name: "lang",
+					In:   "query",
+				}: params.Lang,
+				{
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
+			},
+			Raw: r,
+		}
+
+		type (
+			Request  = struct{}
+			Params   = GetWallpapersParams
+			Response = GetWallpapersRes
+		)
+		response, err = middleware.HookMiddleware[
+			Request,
+			Params,
+			Response,
+		](
+			m,
+			mreq,
+			unpackGetWallpapersParams,
+			func(ctx context.Context, request Request, params Params) (response Response, err error) {
+				response, err = s.h.GetWallpapers(ctx, params)
+				return response, err
+			},
+		)
+	} else {
+		response, err = s.h.GetWallpapers(ctx, params)
+	}
+	if err != nil {
+		defer recordError("Internal", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
+
+	if err := encodeGetWallpapersResponse(response, w, span); err != nil {
+		defer recordError("EncodeResponse", err)
+		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
+			s.cfg.ErrorHandler(ctx, w, r, err)
+		}
+		return
+	}
+}
+
+// handleGetWallpapersByCountryRequest handles getWallpapersByCountry operation.
+//
+// Returns a list of wallpapers taken in the given country.
+//
+// GET /countries/{code}/wallpapers
+func (s *Server) handleGetWallpapersByCountryRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	statusWriter := &codeRecorder{ResponseWriter: w}
 	w = statusWriter
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getRoot"),
+		otelogen.OperationID("getWallpapersByCountry"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/"),
+		semconv.HTTPRouteKey.String("/countries/{code}/wallpapers"),
 	}
 
 	// Start a span for this request.
-	ctx, span := s.cfg.Tracer.Start(r.Context(), GetRootOperation,
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersByCountryOperation,
 		trace.WithAttributes(otelAttrs...),
 		serverSpanKind,
 	)
@@ -99,28 +1341,67 @@ func (s *Server) handleGetRootRequest(args [0]string, argsEscaped bool, w http.R
 
 			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
 		}
-		err error
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetWallpapersByCountryOperation,
+			ID:   "getWallpapersByCountry",
+		}
 	)
+	params, err := decodeGetWallpapersByCountryParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
 
 	var rawBody []byte
 
-	var response *GetRootOK
+	var response GetWallpapersByCountryRes
 	if m := s.cfg.Middleware; m != nil {
 		mreq := middleware.Request{
 			Context:          ctx,
-			OperationName:    GetRootOperation,
-			OperationSummary: "",
-			OperationID:      "getRoot",
+			OperationName:    GetWallpapersByCountryOperation,
+			OperationSummary: "Returns a list of wallpapers taken in the given country",
+			OperationID:      "getWallpapersByCountry",
 			Body:             nil,
 			RawBody:          rawBody,
-			Params:           middleware.Parameters{},
-			Raw:              r,
+			Params: middleware.Parameters{
+				{
+					Name: "code",
+					In:   "path",
+				}: params.Code,
+				{
+					Name: "startAfterDate",
+					In:   "query",
+				}: params.StartAfterDate,
+				{
+					Name: "startAfterID",
+					In:   "query",
+				}: params.StartAfterID,
+				{
+					Name: "safe",
+					In:   "query",
+				}: params.Safe,
+				{
+					Name: "lang",
+					In:   "query",
+				}: params.Lang,
+				{
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
+			},
+			Raw: r,
 		}
 
 		type (
 			Request  = struct{}
-			Params   = struct{}
-			Response = *GetRootOK
+			Params   = GetWallpapersByCountryParams
+			Response = GetWallpapersByCountryRes
 		)
 		response, err = middleware.HookMiddleware[
 			Request,
@@ -129,14 +1410,14 @@ func (s *Server) handleGetRootRequest(args [0]string, argsEscaped bool, w http.R
 		](
 			m,
 			mreq,
-			nil,
+			unpackGetWallpapersByCountryParams,
 			func(ctx context.Context, request Request, params Params) (response Response, err error) {
-				err = s.h.GetRoot(ctx)
+				response, err = s.h.GetWallpapersByCountry(ctx, params)
 				return response, err
 			},
 		)
 	} else {
-		err = s.h.GetRoot(ctx)
+		response, err = s.h.GetWallpapersByCountry(ctx, params)
 	}
 	if err != nil {
 		defer recordError("Internal", err)
@@ -144,7 +1425,7 @@ func (s *Server) handleGetRootRequest(args [0]string, argsEscaped bool, w http.R
 		return
 	}
 
-	if err := encodeGetRootResponse(response, w, span); err != nil {
+	if err := encodeGetWallpapersByCountryResponse(response, w, span); err != nil {
 		defer recordError("EncodeResponse", err)
 		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
 			s.cfg.ErrorHandler(ctx, w, r, err)
@@ -153,22 +1434,22 @@ func (s *Server) handleGetRootRequest(args [0]string, argsEscaped bool, w http.R
 	}
 }
 
-// handleGetWallpaperRequest handles getWallpaper operation.
+// handleGetWallpapersByLandmarkRequest handles getWallpapersByLandmark operation.
 //
-// Returns a wallpaper with the given ID.
+// Returns a list of wallpapers showing the given landmark.
 //
-// GET /wallpapers/{id}
-func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+// GET /landmarks/{slug}/wallpapers
+func (s *Server) handleGetWallpapersByLandmarkRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	statusWriter := &codeRecorder{ResponseWriter: w}
 	w = statusWriter
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getWallpaper"),
+		otelogen.OperationID("getWallpapersByLandmark"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/wallpapers/{id}"),
+		semconv.HTTPRouteKey.String("/landmarks/{slug}/wallpapers"),
 	}
 
 	// Start a span for this request.
-	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpaperOperation,
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersByLandmarkOperation,
 		trace.WithAttributes(otelAttrs...),
 		serverSpanKind,
 	)
@@ -223,11 +1504,11 @@ func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w h
 		}
 		err          error
 		opErrContext = ogenerrors.OperationContext{
-			Name: GetWallpaperOperation,
-			ID:   "getWallpaper",
+			Name: GetWallpapersByLandmarkOperation,
+			ID:   "getWallpapersByLandmark",
 		}
 	)
-	params, err := decodeGetWallpaperParams(args, argsEscaped, r)
+	params, err := decodeGetWallpapersByLandmarkParams(args, argsEscaped, r)
 	if err != nil {
 		err = &ogenerrors.DecodeParamsError{
 			OperationContext: opErrContext,
@@ -240,28 +1521,48 @@ func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w h
 
 	var rawBody []byte
 
-	var response GetWallpaperRes
+	var response GetWallpapersByLandmarkRes
 	if m := s.cfg.Middleware; m != nil {
 		mreq := middleware.Request{
 			Context:          ctx,
-			OperationName:    GetWallpaperOperation,
-			OperationSummary: "Returns a wallpaper with the given ID",
-			OperationID:      "getWallpaper",
+			OperationName:    GetWallpapersByLandmarkOperation,
+			OperationSummary: "Returns a list of wallpapers showing the given landmark",
+			OperationID:      "getWallpapersByLandmark",
 			Body:             nil,
 			RawBody:          rawBody,
 			Params: middleware.Parameters{
 				{
-					Name: "id",
+					Name: "slug",
 					In:   "path",
-				}: params.ID,
+				}: params.Slug,
+				{
+					Name: "startAfterDate",
+					In:   "query",
+				}: params.StartAfterDate,
+				{
+					Name: "startAfterID",
+					In:   "query",
+				}: params.StartAfterID,
+				{
+					Name: "safe",
+					In:   "query",
+				}: params.Safe,
+				{
+					Name: "lang",
+					In:   "query",
+				}: params.Lang,
+				{
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
 			},
 			Raw: r,
 		}
 
 		type (
 			Request  = struct{}
-			Params   = GetWallpaperParams
-			Response = GetWallpaperRes
+			Params   = GetWallpapersByLandmarkParams
+			Response = GetWallpapersByLandmarkRes
 		)
 		response, err = middleware.HookMiddleware[
 			Request,
@@ -270,14 +1571,14 @@ func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w h
 		](
 			m,
 			mreq,
-			unpackGetWallpaperParams,
+			unpackGetWallpapersByLandmarkParams,
 			func(ctx context.Context, request Request, params Params) (response Response, err error) {
-				response, err = s.h.GetWallpaper(ctx, params)
+				response, err = s.h.GetWallpapersByLandmark(ctx, params)
 				return response, err
 			},
 		)
 	} else {
-		response, err = s.h.GetWallpaper(ctx, params)
+		response, err = s.h.GetWallpapersByLandmark(ctx, params)
 	}
 	if err != nil {
 		defer recordError("Internal", err)
@@ -285,7 +1586,7 @@ func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w h
 		return
 	}
 
-	if err := encodeGetWallpaperResponse(response, w, span); err != nil {
+	if err := encodeGetWallpapersByLandmarkResponse(response, w, span); err != nil {
 		defer recordError("EncodeResponse", err)
 		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
 			s.cfg.ErrorHandler(ctx, w, r, err)
@@ -294,22 +1595,22 @@ func (s *Server) handleGetWallpaperRequest(args [1]string, argsEscaped bool, w h
 	}
 }
 
-// handleGetWallpaperTagsRequest handles getWallpaperTags operation.
+// handleGetWallpapersByPhotographerRequest handles getWallpapersByPhotographer operation.
 //
-// Returns a list of tags.
+// Returns a list of wallpapers by the given photographer.
 //
-// GET /wallpapers/tags
-func (s *Server) handleGetWallpaperTagsRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+// GET /photographers/{slug}/wallpapers
+func (s *Server) handleGetWallpapersByPhotographerRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	statusWriter := &codeRecorder{ResponseWriter: w}
 	w = statusWriter
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getWallpaperTags"),
+		otelogen.OperationID("getWallpapersByPhotographer"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/wallpapers/tags"),
+		semconv.HTTPRouteKey.String("/photographers/{slug}/wallpapers"),
 	}
 
 	// Start a span for this request.
-	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpaperTagsOperation,
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersByPhotographerOperation,
 		trace.WithAttributes(otelAttrs...),
 		serverSpanKind,
 	)
@@ -362,28 +1663,67 @@ func (s *Server) handleGetWallpaperTagsRequest(args [0]string, argsEscaped bool,
 
 			s.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
 		}
-		err error
+		err          error
+		opErrContext = ogenerrors.OperationContext{
+			Name: GetWallpapersByPhotographerOperation,
+			ID:   "getWallpapersByPhotographer",
+		}
 	)
+	params, err := decodeGetWallpapersByPhotographerParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		defer recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
 
 	var rawBody []byte
 
-	var response GetWallpaperTagsRes
+	var response GetWallpapersByPhotographerRes
 	if m := s.cfg.Middleware; m != nil {
 		mreq := middleware.Request{
 			Context:          ctx,
-			OperationName:    GetWallpaperTagsOperation,
-			OperationSummary: "Returns a list of tags",
-			OperationID:      "getWallpaperTags",
+			OperationName:    GetWallpapersByPhotographerOperation,
+			OperationSummary: "Returns a list of wallpapers by the given photographer",
+			OperationID:      "getWallpapersByPhotographer",
 			Body:             nil,
 			RawBody:          rawBody,
-			Params:           middleware.Parameters{},
-			Raw:              r,
+			Params: middleware.Parameters{
+				{
+					Name: "slug",
+					In:   "path",
+				}: params.Slug,
+				{
+					Name: "startAfterDate",
+					In:   "query",
+				}: params.StartAfterDate,
+				{
+					Name: "startAfterID",
+					In:   "query",
+				}: params.StartAfterID,
+				{
+					Name: "safe",
+					In:   "query",
+				}: params.Safe,
+				{
+					Name: "lang",
+					In:   "query",
+				}: params.Lang,
+				{
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
+			},
+			Raw: r,
 		}
 
 		type (
 			Request  = struct{}
-			Params   = struct{}
-			Response = GetWallpaperTagsRes
+			Params   = GetWallpapersByPhotographerParams
+			Response = GetWallpapersByPhotographerRes
 		)
 		response, err = middleware.HookMiddleware[
 			Request,
@@ -392,14 +1732,14 @@ func (s *Server) handleGetWallpaperTagsRequest(args [0]string, argsEscaped bool,
 		](
 			m,
 			mreq,
-			nil,
+			unpackGetWallpapersByPhotographerParams,
 			func(ctx context.Context, request Request, params Params) (response Response, err error) {
-				response, err = s.h.GetWallpaperTags(ctx)
+				response, err = s.h.GetWallpapersByPhotographer(ctx, params)
 				return response, err
 			},
 		)
 	} else {
-		response, err = s.h.GetWallpaperTags(ctx)
+		response, err = s.h.GetWallpapersByPhotographer(ctx, params)
 	}
 	if err != nil {
 		defer recordError("Internal", err)
@@ -407,7 +1747,7 @@ func (s *Server) handleGetWallpaperTagsRequest(args [0]string, argsEscaped bool,
 		return
 	}
 
-	if err := encodeGetWallpaperTagsResponse(response, w, span); err != nil {
+	if err := encodeGetWallpapersByPhotographerResponse(response, w, span); err != nil {
 		defer recordError("EncodeResponse", err)
 		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
 			s.cfg.ErrorHandler(ctx, w, r, err)
@@ -416,22 +1756,22 @@ func (s *Server) handleGetWallpaperTagsRequest(args [0]string, argsEscaped bool,
 	}
 }
 
-// handleGetWallpapersRequest handles getWallpapers operation.
+// handleGetWallpapersByTagRequest handles getWallpapersByTag operation.
 //
-// Returns a list of wallpapers.
+// Returns a list of wallpapers with the given tag.
 //
-// GET /wallpapers
-func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+// GET /wallpapers/tags/{tag}
+func (s *Server) handleGetWallpapersByTagRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	statusWriter := &codeRecorder{ResponseWriter: w}
 	w = statusWriter
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getWallpapers"),
+		otelogen.OperationID("getWallpapersByTag"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/wallpapers"),
+		semconv.HTTPRouteKey.String("/wallpapers/tags/{tag}"),
 	}
 
 	// Start a span for this request.
-	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersOperation,
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersByTagOperation,
 		trace.WithAttributes(otelAttrs...),
 		serverSpanKind,
 	)
@@ -486,11 +1826,11 @@ func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w
 		}
 		err          error
 		opErrContext = ogenerrors.OperationContext{
-			Name: GetWallpapersOperation,
-			ID:   "getWallpapers",
+			Name: GetWallpapersByTagOperation,
+			ID:   "getWallpapersByTag",
 		}
 	)
-	params, err := decodeGetWallpapersParams(args, argsEscaped, r)
+	params, err := decodeGetWallpapersByTagParams(args, argsEscaped, r)
 	if err != nil {
 		err = &ogenerrors.DecodeParamsError{
 			OperationContext: opErrContext,
@@ -503,40 +1843,40 @@ func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w
 
 	var rawBody []byte
 
-	var response GetWallpapersRes
+	var response GetWallpapersByTagRes
 	if m := s.cfg.Middleware; m != nil {
 		mreq := middleware.Request{
 			Context:          ctx,
-			OperationName:    GetWallpapersOperation,
-			OperationSummary: "Returns a list of wallpapers",
-			OperationID:      "getWallpapers",
+			OperationName:    GetWallpapersByTagOperation,
+			OperationSummary: "Returns a list of wallpapers with the given tag",
+			OperationID:      "getWallpapersByTag",
 			Body:             nil,
 			RawBody:          rawBody,
 			Params: middleware.Parameters{
 				{
-					Name: "limit",
-					In:   "query",
-				}: params.Limit,
+					Name: "tag",
+					In:   "path",
+				}: params.Tag,
 				{
-					Name: "startAfterDate",
+					Name: "after",
 					In:   "query",
-				}: params.StartAfterDate,
+				}: params.After,
 				{
-					Name: "startAfterID",
+					Name: "lang",
 					In:   "query",
-				}: params.StartAfterID,
+				}: params.Lang,
 				{
-					Name: "prev",
-					In:   "query",
-				}: params.Prev,
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
 			},
 			Raw: r,
 		}
 
 		type (
 			Request  = struct{}
-			Params   = GetWallpapersParams
-			Response = GetWallpapersRes
+			Params   = GetWallpapersByTagParams
+			Response = GetWallpapersByTagRes
 		)
 		response, err = middleware.HookMiddleware[
 			Request,
@@ -545,14 +1885,14 @@ func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w
 		](
 			m,
 			mreq,
-			unpackGetWallpapersParams,
+			unpackGetWallpapersByTagParams,
 			func(ctx context.Context, request Request, params Params) (response Response, err error) {
-				response, err = s.h.GetWallpapers(ctx, params)
+				response, err = s.h.GetWallpapersByTag(ctx, params)
 				return response, err
 			},
 		)
 	} else {
-		response, err = s.h.GetWallpapers(ctx, params)
+		response, err = s.h.GetWallpapersByTag(ctx, params)
 	}
 	if err != nil {
 		defer recordError("Internal", err)
@@ -560,7 +1900,7 @@ func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w
 		return
 	}
 
-	if err := encodeGetWallpapersResponse(response, w, span); err != nil {
+	if err := encodeGetWallpapersByTagResponse(response, w, span); err != nil {
 		defer recordError("EncodeResponse", err)
 		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
 			s.cfg.ErrorHandler(ctx, w, r, err)
@@ -569,22 +1909,22 @@ func (s *Server) handleGetWallpapersRequest(args [0]string, argsEscaped bool, w
 	}
 }
 
-// handleGetWallpapersByTagRequest handles getWallpapersByTag operation.
+// handleGetWallpapersNearRequest handles getWallpapersNear operation.
 //
-// Returns a list of wallpapers with the given tag.
+// Returns wallpapers taken near a location, nearest first.
 //
-// GET /wallpapers/tags/{tag}
-func (s *Server) handleGetWallpapersByTagRequest(args [1]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
+// GET /wallpapers/near
+func (s *Server) handleGetWallpapersNearRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	statusWriter := &codeRecorder{ResponseWriter: w}
 	w = statusWriter
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getWallpapersByTag"),
+		otelogen.OperationID("getWallpapersNear"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/wallpapers/tags/{tag}"),
+		semconv.HTTPRouteKey.String("/wallpapers/near"),
 	}
 
 	// Start a span for this request.
-	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersByTagOperation,
+	ctx, span := s.cfg.Tracer.Start(r.Context(), GetWallpapersNearOperation,
 		trace.WithAttributes(otelAttrs...),
 		serverSpanKind,
 	)
@@ -639,11 +1979,11 @@ func (s *Server) handleGetWallpapersByTagRequest(args [1]string, argsEscaped boo
 		}
 		err          error
 		opErrContext = ogenerrors.OperationContext{
-			Name: GetWallpapersByTagOperation,
-			ID:   "getWallpapersByTag",
+			Name: GetWallpapersNearOperation,
+			ID:   "getWallpapersNear",
 		}
 	)
-	params, err := decodeGetWallpapersByTagParams(args, argsEscaped, r)
+	params, err := decodeGetWallpapersNearParams(args, argsEscaped, r)
 	if err != nil {
 		err = &ogenerrors.DecodeParamsError{
 			OperationContext: opErrContext,
@@ -656,32 +1996,52 @@ func (s *Server) handleGetWallpapersByTagRequest(args [1]string, argsEscaped boo
 
 	var rawBody []byte
 
-	var response GetWallpapersByTagRes
+	var response GetWallpapersNearRes
 	if m := s.cfg.Middleware; m != nil {
 		mreq := middleware.Request{
 			Context:          ctx,
-			OperationName:    GetWallpapersByTagOperation,
-			OperationSummary: "Returns a list of wallpapers with the given tag",
-			OperationID:      "getWallpapersByTag",
+			OperationName:    GetWallpapersNearOperation,
+			OperationSummary: "Returns wallpapers taken near a location, nearest first",
+			OperationID:      "getWallpapersNear",
 			Body:             nil,
 			RawBody:          rawBody,
 			Params: middleware.Parameters{
 				{
-					Name: "tag",
-					In:   "path",
-				}: params.Tag,
+					Name: "lat",
+					In:   "query",
+				}: params.Lat,
 				{
-					Name: "after",
+					Name: "lng",
 					In:   "query",
-				}: params.After,
+				}: params.Lng,
+				{
+					Name: "radius",
+					In:   "query",
+				}: params.Radius,
+				{
+					Name: "limit",
+					In:   "query",
+				}: params.Limit,
+				{
+					Name: "safe",
+					In:   "query",
+				}: params.Safe,
+				{
+					Name: "lang",
+					In:   "query",
+				}: params.Lang,
+				{
+					Name: "Accept-Language",
+					In:   "header",
+				}: params.AcceptLanguage,
 			},
 			Raw: r,
 		}
 
 		type (
 			Request  = struct{}
-			Params   = GetWallpapersByTagParams
-			Response = GetWallpapersByTagRes
+			Params   = GetWallpapersNearParams
+			Response = GetWallpapersNearRes
 		)
 		response, err = middleware.HookMiddleware[
 			Request,
@@ -690,14 +2050,14 @@ func (s *Server) handleGetWallpapersByTagRequest(args [1]string, argsEscaped boo
 		](
 			m,
 			mreq,
-			unpackGetWallpapersByTagParams,
+			unpackGetWallpapersNearParams,
 			func(ctx context.Context, request Request, params Params) (response Response, err error) {
-				response, err = s.h.GetWallpapersByTag(ctx, params)
+				response, err = s.h.GetWallpapersNear(ctx, params)
 				return response, err
 			},
 		)
 	} else {
-		response, err = s.h.GetWallpapersByTag(ctx, params)
+		response, err = s.h.GetWallpapersNear(ctx, params)
 	}
 	if err != nil {
 		defer recordError("Internal", err)
@@ -705,7 +2065,7 @@ func (s *Server) handleGetWallpapersByTagRequest(args [1]string, argsEscaped boo
 		return
 	}
 
-	if err := encodeGetWallpapersByTagResponse(response, w, span); err != nil {
+	if err := encodeGetWallpapersNearResponse(response, w, span); err != nil {
 		defer recordError("EncodeResponse", err)
 		if !errors.Is(err, ht.ErrInternalServerErrorResponse) {
 			s.cfg.ErrorHandler(ctx, w, r, err)
@@ -1,6 +1,14 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type GetJobRes interface {
+	getJobRes()
+}
+
+type GetWallpaperImageRes interface {
+	getWallpaperImageRes()
+}
+
 type GetWallpaperRes interface {
 	getWallpaperRes()
 }
@@ -9,10 +17,26 @@ type GetWallpaperTagsRes interface {
 	getWallpaperTagsRes()
 }
 
+type GetWallpapersByCountryRes interface {
+	getWallpapersByCountryRes()
+}
+
+type GetWallpapersByLandmarkRes interface {
+	getWallpapersByLandmarkRes()
+}
+
+type GetWallpapersByPhotographerRes interface {
+	getWallpapersByPhotographerRes()
+}
+
 type GetWallpapersByTagRes interface {
 	getWallpapersByTagRes()
 }
 
+type GetWallpapersNearRes interface {
+	getWallpapersNearRes()
+}
+
 type GetWallpapersRes interface {
 	getWallpapersRes()
 }
@@ -6,9 +6,17 @@ package api
 type OperationName = string
 
 const (
-	GetRootOperation            OperationName = "GetRoot"
-	GetWallpaperOperation       OperationName = "GetWallpaper"
-	GetWallpaperTagsOperation   OperationName = "GetWallpaperTags"
-	GetWallpapersOperation      OperationName = "GetWallpapers"
-	GetWallpapersByTagOperation OperationName = "GetWallpapersByTag"
+	GetJobOperation                      OperationName = "GetJob"
+	GetJobsOperation                     OperationName = "GetJobs"
+	GetPhotographersOperation            OperationName = "GetPhotographers"
+	GetRootOperation                     OperationName = "GetRoot"
+	GetWallpaperOperation                OperationName = "GetWallpaper"
+	GetWallpaperImageOperation           OperationName = "GetWallpaperImage"
+	GetWallpaperTagsOperation            OperationName = "GetWallpaperTags"
+	GetWallpapersOperation               OperationName = "GetWallpapers"
+	GetWallpapersByCountryOperation      OperationName = "GetWallpapersByCountry"
+	GetWallpapersByLandmarkOperation     OperationName = "GetWallpapersByLandmark"
+	GetWallpapersByPhotographerOperation OperationName = "GetWallpapersByPhotographer"
+	GetWallpapersByTagOperation          OperationName = "GetWallpapersByTag"
+	GetWallpapersNearOperation           OperationName = "GetWallpapersNear"
 )
@@ -14,23 +14,23 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
-// GetWallpaperParams is parameters of getWallpaper operation.
-type GetWallpaperParams struct {
-	ID ID
+// GetJobParams is parameters of getJob operation.
+type GetJobParams struct {
+	ID string
 }
 
-func unpackGetWallpaperParams(packed middleware.Parameters) (params GetWallpaperParams) {
+func unpackGetJobParams(packed middleware.Parameters) (params GetJobParams) {
 	{
 		key := middleware.ParameterKey{
 			Name: "id",
 			In:   "path",
 		}
-		params.ID = packed[key].(ID)
+		params.ID = packed[key].(string)
 	}
 	return params
 }
 
-func decodeGetWallpaperParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpaperParams, _ error) {
+func decodeGetJobParams(args [1]string, argsEscaped bool, r *http.Request) (params GetJobParams, _ error) {
 	// Decode path: id.
 	if err := func() error {
 		param := args[0]
@@ -50,24 +50,17 @@ func decodeGetWallpaperParams(args [1]string, argsEscaped bool, r *http.Request)
 			})
 
 			if err := func() error {
-				var paramsDotIDVal string
-				if err := func() error {
-					val, err := d.DecodeValue()
-					if err != nil {
-						return err
-					}
-
-					c, err := conv.ToString(val)
-					if err != nil {
-						return err
-					}
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
 
-					paramsDotIDVal = c
-					return nil
-				}(); err != nil {
+				c, err := conv.ToString(val)
+				if err != nil {
 					return err
 				}
-				params.ID = ID(paramsDotIDVal)
+
+				params.ID = c
 				return nil
 			}(); err != nil {
 				return err
@@ -86,15 +79,14 @@ func decodeGetWallpaperParams(args [1]string, argsEscaped bool, r *http.Request)
 	return params, nil
 }
 
-// GetWallpapersParams is parameters of getWallpapers operation.
-type GetWallpapersParams struct {
-	Limit          OptInt               `json:",omitempty,omitzero"`
-	StartAfterDate OptDate              `json:",omitempty,omitzero"`
-	StartAfterID   OptID                `json:",omitempty,omitzero"`
-	Prev           OptGetWallpapersPrev `json:",omitempty,omitzero"`
+// GetJobsParams is parameters of getJobs operation.
+type GetJobsParams struct {
+	Limit OptInt `json:",omitempty,omitzero"`
+	// Returns the jobs started before this one.
+	StartAfterID OptString `json:",omitempty,omitzero"`
 }
 
-func unpackGetWallpapersParams(packed middleware.Parameters) (params GetWallpapersParams) {
+func unpackGetJobsParams(packed middleware.Parameters) (params GetJobsParams) {
 	{
 		key := middleware.ParameterKey{
 			Name: "limit",
@@ -104,37 +96,19 @@ func unpackGetWallpapersParams(packed middleware.Parameters) (params GetWallpape
 			params.Limit = v.(OptInt)
 		}
 	}
-	{
-		key := middleware.ParameterKey{
-			Name: "startAfterDate",
-			In:   "query",
-		}
-		if v, ok := packed[key]; ok {
-			params.StartAfterDate = v.(OptDate)
-		}
-	}
 	{
 		key := middleware.ParameterKey{
 			Name: "startAfterID",
 			In:   "query",
 		}
 		if v, ok := packed[key]; ok {
-			params.StartAfterID = v.(OptID)
-		}
-	}
-	{
-		key := middleware.ParameterKey{
-			Name: "prev",
-			In:   "query",
-		}
-		if v, ok := packed[key]; ok {
-			params.Prev = v.(OptGetWallpapersPrev)
+			params.StartAfterID = v.(OptString)
 		}
 	}
 	return params
 }
 
-func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request) (params GetWallpapersParams, _ error) {
+func decodeGetJobsParams(args [0]string, argsEscaped bool, r *http.Request) (params GetJobsParams, _ error) {
 	q := uri.NewQueryDecoder(r.URL.Query())
 	// Decode query: limit.
 	if err := func() error {
@@ -175,7 +149,7 @@ func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request
 							MinSet:        true,
 							Min:           1,
 							MaxSet:        true,
-							Max:           24,
+							Max:           100,
 							MinExclusive:  false,
 							MaxExclusive:  false,
 							MultipleOfSet: false,
@@ -202,128 +176,81 @@ func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request
 			Err:  err,
 		}
 	}
-	// Decode query: startAfterDate.
+	// Decode query: startAfterID.
 	if err := func() error {
 		cfg := uri.QueryParameterDecodingConfig{
-			Name:    "startAfterDate",
+			Name:    "startAfterID",
 			Style:   uri.QueryStyleForm,
 			Explode: true,
 		}
 
 		if err := q.HasParam(cfg); err == nil {
 			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				var paramsDotStartAfterDateVal Date
+				var paramsDotStartAfterIDVal string
 				if err := func() error {
-					var paramsDotStartAfterDateValVal int
-					if err := func() error {
-						val, err := d.DecodeValue()
-						if err != nil {
-							return err
-						}
-
-						c, err := conv.ToInt(val)
-						if err != nil {
-							return err
-						}
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
 
-						paramsDotStartAfterDateValVal = c
-						return nil
-					}(); err != nil {
+					c, err := conv.ToString(val)
+					if err != nil {
 						return err
 					}
-					paramsDotStartAfterDateVal = Date(paramsDotStartAfterDateValVal)
+
+					paramsDotStartAfterIDVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.StartAfterDate.SetTo(paramsDotStartAfterDateVal)
+				params.StartAfterID.SetTo(paramsDotStartAfterIDVal)
 				return nil
 			}); err != nil {
 				return err
 			}
-			if err := func() error {
-				if value, ok := params.StartAfterDate.Get(); ok {
-					if err := func() error {
-						if err := value.Validate(); err != nil {
-							return err
-						}
-						return nil
-					}(); err != nil {
-						return err
-					}
-				}
-				return nil
-			}(); err != nil {
-				return err
-			}
 		}
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "startAfterDate",
+			Name: "startAfterID",
 			In:   "query",
 			Err:  err,
 		}
 	}
-	// Decode query: startAfterID.
-	if err := func() error {
-		cfg := uri.QueryParameterDecodingConfig{
-			Name:    "startAfterID",
-			Style:   uri.QueryStyleForm,
-			Explode: true,
-		}
-
-		if err := q.HasParam(cfg); err == nil {
-			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				var paramsDotStartAfterIDVal ID
-				if err := func() error {
-					var paramsDotStartAfterIDValVal string
-					if err := func() error {
-						val, err := d.DecodeValue()
-						if err != nil {
-							return err
-						}
+	return params, nil
+}
 
-						c, err := conv.ToString(val)
-						if err != nil {
-							return err
-						}
+// GetPhotographersParams is parameters of getPhotographers operation.
+type GetPhotographersParams struct {
+	Limit OptInt `json:",omitempty,omitzero"`
+}
 
-						paramsDotStartAfterIDValVal = c
-						return nil
-					}(); err != nil {
-						return err
-					}
-					paramsDotStartAfterIDVal = ID(paramsDotStartAfterIDValVal)
-					return nil
-				}(); err != nil {
-					return err
-				}
-				params.StartAfterID.SetTo(paramsDotStartAfterIDVal)
-				return nil
-			}); err != nil {
-				return err
-			}
-		}
-		return nil
-	}(); err != nil {
-		return params, &ogenerrors.DecodeParamError{
-			Name: "startAfterID",
+func unpackGetPhotographersParams(packed middleware.Parameters) (params GetPhotographersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "limit",
 			In:   "query",
-			Err:  err,
+		}
+		if v, ok := packed[key]; ok {
+			params.Limit = v.(OptInt)
 		}
 	}
-	// Decode query: prev.
+	return params
+}
+
+func decodeGetPhotographersParams(args [0]string, argsEscaped bool, r *http.Request) (params GetPhotographersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: limit.
 	if err := func() error {
 		cfg := uri.QueryParameterDecodingConfig{
-			Name:    "prev",
+			Name:    "limit",
 			Style:   uri.QueryStyleForm,
 			Explode: true,
 		}
 
 		if err := q.HasParam(cfg); err == nil {
 			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				var paramsDotPrevVal GetWallpapersPrev
+				var paramsDotLimitVal int
 				if err := func() error {
 					val, err := d.DecodeValue()
 					if err != nil {
@@ -335,21 +262,31 @@ func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request
 						return err
 					}
 
-					paramsDotPrevVal = GetWallpapersPrev(c)
+					paramsDotLimitVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.Prev.SetTo(paramsDotPrevVal)
+				params.Limit.SetTo(paramsDotLimitVal)
 				return nil
 			}); err != nil {
 				return err
 			}
 			if err := func() error {
-				if value, ok := params.Prev.Get(); ok {
+				if value, ok := params.Limit.Get(); ok {
 					if err := func() error {
-						if err := value.Validate(); err != nil {
-							return err
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        true,
+							Max:           500,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+							Pattern:       nil,
+						}).Validate(int64(value)); err != nil {
+							return errors.Wrap(err, "int")
 						}
 						return nil
 					}(); err != nil {
@@ -364,7 +301,7 @@ func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "prev",
+			Name: "limit",
 			In:   "query",
 			Err:  err,
 		}
@@ -372,35 +309,47 @@ func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request
 	return params, nil
 }
 
-// GetWallpapersByTagParams is parameters of getWallpapersByTag operation.
-type GetWallpapersByTagParams struct {
-	Tag   string
-	After OptFloat64 `json:",omitempty,omitzero"`
+// GetWallpaperParams is parameters of getWallpaper operation.
+type GetWallpaperParams struct {
+	ID ID
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
 }
 
-func unpackGetWallpapersByTagParams(packed middleware.Parameters) (params GetWallpapersByTagParams) {
+func unpackGetWallpaperParams(packed middleware.Parameters) (params GetWallpaperParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "tag",
+			Name: "id",
 			In:   "path",
 		}
-		params.Tag = packed[key].(string)
+		params.ID = packed[key].(ID)
 	}
 	{
 		key := middleware.ParameterKey{
-			Name: "after",
+			Name: "lang",
 			In:   "query",
 		}
 		if v, ok := packed[key]; ok {
-			params.After = v.(OptFloat64)
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
 		}
 	}
 	return params
 }
 
-func decodeGetWallpapersByTagParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpapersByTagParams, _ error) {
+func decodeGetWallpaperParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpaperParams, _ error) {
 	q := uri.NewQueryDecoder(r.URL.Query())
-	// Decode path: tag.
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode path: id.
 	if err := func() error {
 		param := args[0]
 		if argsEscaped {
@@ -412,24 +361,31 @@ func decodeGetWallpapersByTagParams(args [1]string, argsEscaped bool, r *http.Re
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "tag",
+				Param:   "id",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
 			})
 
 			if err := func() error {
-				val, err := d.DecodeValue()
-				if err != nil {
-					return err
-				}
+				var paramsDotIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
 
-				c, err := conv.ToString(val)
-				if err != nil {
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotIDVal = c
+					return nil
+				}(); err != nil {
 					return err
 				}
-
-				params.Tag = c
+				params.ID = ID(paramsDotIDVal)
 				return nil
 			}(); err != nil {
 				return err
@@ -440,66 +396,2880 @@ func decodeGetWallpapersByTagParams(args [1]string, argsEscaped bool, r *http.Re
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "tag",
+			Name: "id",
 			In:   "path",
 			Err:  err,
 		}
 	}
-	// Decode query: after.
+	// Decode query: lang.
 	if err := func() error {
 		cfg := uri.QueryParameterDecodingConfig{
-			Name:    "after",
+			Name:    "lang",
 			Style:   uri.QueryStyleForm,
 			Explode: true,
 		}
 
 		if err := q.HasParam(cfg); err == nil {
 			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
-				var paramsDotAfterVal float64
+				var paramsDotLangVal string
 				if err := func() error {
 					val, err := d.DecodeValue()
 					if err != nil {
 						return err
 					}
 
-					c, err := conv.ToFloat64(val)
+					c, err := conv.ToString(val)
 					if err != nil {
 						return err
 					}
 
-					paramsDotAfterVal = c
+					paramsDotLangVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.After.SetTo(paramsDotAfterVal)
+				params.Lang.SetTo(paramsDotLangVal)
 				return nil
 			}); err != nil {
 				return err
 			}
-			if err := func() error {
-				if value, ok := params.After.Get(); ok {
-					if err := func() error {
-						if err := (validate.Float{}).Validate(float64(value)); err != nil {
-							return errors.Wrap(err, "float")
-						}
-						return nil
-					}(); err != nil {
-						return err
-					}
-				}
-				return nil
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpaperImageParams is parameters of getWallpaperImage operation.
+type GetWallpaperImageParams struct {
+	ID ID
+	// Width in pixels.
+	W OptInt `json:",omitempty,omitzero"`
+	// Height in pixels.
+	H OptInt `json:",omitempty,omitzero"`
+	// With both w and h, contain scales the image to fit inside them and cover scales and crops it to
+	// fill them.
+	Fit    OptGetWallpaperImageFit    `json:",omitempty,omitzero"`
+	Format OptGetWallpaperImageFormat `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpaperImageParams(packed middleware.Parameters) (params GetWallpaperImageParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(ID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "w",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.W = v.(OptInt)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "h",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.H = v.(OptInt)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "fit",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Fit = v.(OptGetWallpaperImageFit)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "format",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Format = v.(OptGetWallpaperImageFormat)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpaperImageParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpaperImageParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.ID = ID(paramsDotIDVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: w.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "w",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotWVal int
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotWVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.W.SetTo(paramsDotWVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.W.Get(); ok {
+					if err := func() error {
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        true,
+							Max:           3840,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+							Pattern:       nil,
+						}).Validate(int64(value)); err != nil {
+							return errors.Wrap(err, "int")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "w",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: h.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "h",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotHVal int
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotHVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.H.SetTo(paramsDotHVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.H.Get(); ok {
+					if err := func() error {
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        true,
+							Max:           3840,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+							Pattern:       nil,
+						}).Validate(int64(value)); err != nil {
+							return errors.Wrap(err, "int")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "h",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Set default value for query: fit.
+	{
+		val := GetWallpaperImageFit("contain")
+		params.Fit.SetTo(val)
+	}
+	// Decode query: fit.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "fit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotFitVal GetWallpaperImageFit
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotFitVal = GetWallpaperImageFit(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Fit.SetTo(paramsDotFitVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Fit.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "fit",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Set default value for query: format.
+	{
+		val := GetWallpaperImageFormat("jpeg")
+		params.Format.SetTo(val)
+	}
+	// Decode query: format.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "format",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotFormatVal GetWallpaperImageFormat
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotFormatVal = GetWallpaperImageFormat(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Format.SetTo(paramsDotFormatVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Format.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "format",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpapersParams is parameters of getWallpapers operation.
+type GetWallpapersParams struct {
+	Limit          OptInt               `json:",omitempty,omitzero"`
+	StartAfterDate OptDate              `json:",omitempty,omitzero"`
+	StartAfterID   OptID                `json:",omitempty,omitzero"`
+	Prev           OptGetWallpapersPrev `json:",omitempty,omitzero"`
+	// Only return wallpapers that appeared in this market (e.g. "ja-JP").
+	Market OptString `json:",omitempty,omitzero"`
+	// Only return wallpapers from this agency, by slug (e.g. "getty-images").
+	Agency OptString `json:",omitempty,omitzero"`
+	// Only return wallpapers with a variant in this orientation, portrait being the mobile variants.
+	Orientation OptGetWallpapersOrientation `json:",omitempty,omitzero"`
+	// Leave out wallpapers likely to contain adult, racy or violent content.
+	Safe OptSafe `json:",omitempty,omitzero"`
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpapersParams(packed middleware.Parameters) (params GetWallpapersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "limit",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Limit = v.(OptInt)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterDate",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterDate = v.(OptDate)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterID",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterID = v.(OptID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "prev",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Prev = v.(OptGetWallpapersPrev)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "market",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Market = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "agency",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Agency = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "orientation",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Orientation = v.(OptGetWallpapersOrientation)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "safe",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Safe = v.(OptSafe)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lang",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpapersParams(args [0]string, argsEscaped bool, r *http.Request) (params GetWallpapersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode query: limit.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "limit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLimitVal int
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLimitVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Limit.SetTo(paramsDotLimitVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Limit.Get(); ok {
+					if err := func() error {
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        true,
+							Max:           24,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+							Pattern:       nil,
+						}).Validate(int64(value)); err != nil {
+							return errors.Wrap(err, "int")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "limit",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterDate.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterDate",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterDateVal Date
+				if err := func() error {
+					var paramsDotStartAfterDateValVal int
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToInt(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterDateValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterDateVal = Date(paramsDotStartAfterDateValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterDate.SetTo(paramsDotStartAfterDateVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.StartAfterDate.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterDate",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterID.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterID",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterIDVal ID
+				if err := func() error {
+					var paramsDotStartAfterIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterIDVal = ID(paramsDotStartAfterIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterID.SetTo(paramsDotStartAfterIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterID",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: prev.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "prev",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotPrevVal GetWallpapersPrev
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotPrevVal = GetWallpapersPrev(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Prev.SetTo(paramsDotPrevVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Prev.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "prev",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: market.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "market",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotMarketVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotMarketVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Market.SetTo(paramsDotMarketVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "market",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: agency.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "agency",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAgencyVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAgencyVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Agency.SetTo(paramsDotAgencyVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "agency",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: orientation.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "orientation",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotOrientationVal GetWallpapersOrientation
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOrientationVal = GetWallpapersOrientation(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Orientation.SetTo(paramsDotOrientationVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Orientation.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "orientation",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: safe.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "safe",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSafeVal Safe
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSafeVal = Safe(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Safe.SetTo(paramsDotSafeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Safe.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "safe",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lang.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lang",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLangVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLangVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Lang.SetTo(paramsDotLangVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpapersByCountryParams is parameters of getWallpapersByCountry operation.
+type GetWallpapersByCountryParams struct {
+	// ISO 3166-1 alpha-2 country code (e.g. "US").
+	Code           string
+	StartAfterDate OptDate `json:",omitempty,omitzero"`
+	StartAfterID   OptID   `json:",omitempty,omitzero"`
+	// Leave out wallpapers likely to contain adult, racy or violent content.
+	Safe OptSafe `json:",omitempty,omitzero"`
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpapersByCountryParams(packed middleware.Parameters) (params GetWallpapersByCountryParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "code",
+			In:   "path",
+		}
+		params.Code = packed[key].(string)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterDate",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterDate = v.(OptDate)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterID",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterID = v.(OptID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "safe",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Safe = v.(OptSafe)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lang",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpapersByCountryParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpapersByCountryParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode path: code.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "code",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Code = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "code",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterDate.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterDate",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterDateVal Date
+				if err := func() error {
+					var paramsDotStartAfterDateValVal int
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToInt(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterDateValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterDateVal = Date(paramsDotStartAfterDateValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterDate.SetTo(paramsDotStartAfterDateVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.StartAfterDate.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterDate",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterID.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterID",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterIDVal ID
+				if err := func() error {
+					var paramsDotStartAfterIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterIDVal = ID(paramsDotStartAfterIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterID.SetTo(paramsDotStartAfterIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterID",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: safe.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "safe",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSafeVal Safe
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSafeVal = Safe(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Safe.SetTo(paramsDotSafeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Safe.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "safe",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lang.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lang",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLangVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLangVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Lang.SetTo(paramsDotLangVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpapersByLandmarkParams is parameters of getWallpapersByLandmark operation.
+type GetWallpapersByLandmarkParams struct {
+	// Lowercase landmark name with hyphens (e.g. "eiffel-tower").
+	Slug           string
+	StartAfterDate OptDate `json:",omitempty,omitzero"`
+	StartAfterID   OptID   `json:",omitempty,omitzero"`
+	// Leave out wallpapers likely to contain adult, racy or violent content.
+	Safe OptSafe `json:",omitempty,omitzero"`
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpapersByLandmarkParams(packed middleware.Parameters) (params GetWallpapersByLandmarkParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "slug",
+			In:   "path",
+		}
+		params.Slug = packed[key].(string)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterDate",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterDate = v.(OptDate)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterID",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterID = v.(OptID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "safe",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Safe = v.(OptSafe)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lang",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpapersByLandmarkParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpapersByLandmarkParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode path: slug.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "slug",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Slug = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "slug",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterDate.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterDate",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterDateVal Date
+				if err := func() error {
+					var paramsDotStartAfterDateValVal int
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToInt(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterDateValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterDateVal = Date(paramsDotStartAfterDateValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterDate.SetTo(paramsDotStartAfterDateVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.StartAfterDate.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterDate",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterID.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterID",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterIDVal ID
+				if err := func() error {
+					var paramsDotStartAfterIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterIDVal = ID(paramsDotStartAfterIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterID.SetTo(paramsDotStartAfterIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterID",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: safe.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "safe",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSafeVal Safe
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSafeVal = Safe(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Safe.SetTo(paramsDotSafeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Safe.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "safe",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lang.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lang",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLangVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLangVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Lang.SetTo(paramsDotLangVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpapersByPhotographerParams is parameters of getWallpapersByPhotographer operation.
+type GetWallpapersByPhotographerParams struct {
+	Slug           string
+	StartAfterDate OptDate `json:",omitempty,omitzero"`
+	StartAfterID   OptID   `json:",omitempty,omitzero"`
+	// Leave out wallpapers likely to contain adult, racy or violent content.
+	Safe OptSafe `json:",omitempty,omitzero"`
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpapersByPhotographerParams(packed middleware.Parameters) (params GetWallpapersByPhotographerParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "slug",
+			In:   "path",
+		}
+		params.Slug = packed[key].(string)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterDate",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterDate = v.(OptDate)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "startAfterID",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.StartAfterID = v.(OptID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "safe",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Safe = v.(OptSafe)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lang",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpapersByPhotographerParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpapersByPhotographerParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode path: slug.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "slug",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Slug = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "slug",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterDate.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterDate",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterDateVal Date
+				if err := func() error {
+					var paramsDotStartAfterDateValVal int
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToInt(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterDateValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterDateVal = Date(paramsDotStartAfterDateValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterDate.SetTo(paramsDotStartAfterDateVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.StartAfterDate.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterDate",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: startAfterID.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "startAfterID",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotStartAfterIDVal ID
+				if err := func() error {
+					var paramsDotStartAfterIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotStartAfterIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotStartAfterIDVal = ID(paramsDotStartAfterIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.StartAfterID.SetTo(paramsDotStartAfterIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "startAfterID",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: safe.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "safe",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSafeVal Safe
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSafeVal = Safe(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Safe.SetTo(paramsDotSafeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Safe.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "safe",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lang.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lang",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLangVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLangVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Lang.SetTo(paramsDotLangVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpapersByTagParams is parameters of getWallpapersByTag operation.
+type GetWallpapersByTagParams struct {
+	Tag   string
+	After OptFloat64 `json:",omitempty,omitzero"`
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpapersByTagParams(packed middleware.Parameters) (params GetWallpapersByTagParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "tag",
+			In:   "path",
+		}
+		params.Tag = packed[key].(string)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "after",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.After = v.(OptFloat64)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lang",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpapersByTagParams(args [1]string, argsEscaped bool, r *http.Request) (params GetWallpapersByTagParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode path: tag.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "tag",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Tag = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "tag",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: after.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "after",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAfterVal float64
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToFloat64(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAfterVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.After.SetTo(paramsDotAfterVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.After.Get(); ok {
+					if err := func() error {
+						if err := (validate.Float{}).Validate(float64(value)); err != nil {
+							return errors.Wrap(err, "float")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "after",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lang.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lang",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLangVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLangVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Lang.SetTo(paramsDotLangVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetWallpapersNearParams is parameters of getWallpapersNear operation.
+type GetWallpapersNearParams struct {
+	Lat float64
+	Lng float64
+	// Distance in km, defaults to 50.
+	Radius OptFloat64 `json:",omitempty,omitzero"`
+	Limit  OptInt     `json:",omitempty,omitzero"`
+	// Leave out wallpapers likely to contain adult, racy or violent content.
+	Safe OptSafe `json:",omitempty,omitzero"`
+	// Preferred language of titles and copyrights (e.g. "ja"), takes precedence over Accept-Language.
+	Lang           OptString `json:",omitempty,omitzero"`
+	AcceptLanguage OptString `json:",omitempty,omitzero"`
+}
+
+func unpackGetWallpapersNearParams(packed middleware.Parameters) (params GetWallpapersNearParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "lat",
+			In:   "query",
+		}
+		params.Lat = packed[key].(float64)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lng",
+			In:   "query",
+		}
+		params.Lng = packed[key].(float64)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "radius",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Radius = v.(OptFloat64)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "limit",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Limit = v.(OptInt)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "safe",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Safe = v.(OptSafe)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "lang",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Lang = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "Accept-Language",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.AcceptLanguage = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetWallpapersNearParams(args [0]string, argsEscaped bool, r *http.Request) (params GetWallpapersNearParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode query: lat.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lat",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToFloat64(val)
+				if err != nil {
+					return err
+				}
+
+				params.Lat = c
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if err := (validate.Float{
+					MinSet:        true,
+					Min:           -90,
+					MaxSet:        true,
+					Max:           90,
+					MinExclusive:  false,
+					MaxExclusive:  false,
+					MultipleOfSet: false,
+					MultipleOf:    nil,
+					Pattern:       nil,
+				}).Validate(float64(params.Lat)); err != nil {
+					return errors.Wrap(err, "float")
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lat",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lng.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lng",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToFloat64(val)
+				if err != nil {
+					return err
+				}
+
+				params.Lng = c
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if err := (validate.Float{
+					MinSet:        true,
+					Min:           -180,
+					MaxSet:        true,
+					Max:           180,
+					MinExclusive:  false,
+					MaxExclusive:  false,
+					MultipleOfSet: false,
+					MultipleOf:    nil,
+					Pattern:       nil,
+				}).Validate(float64(params.Lng)); err != nil {
+					return errors.Wrap(err, "float")
+				}
+				return nil
 			}(); err != nil {
 				return err
 			}
+		} else {
+			return err
 		}
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "after",
+			Name: "lng",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: radius.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "radius",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotRadiusVal float64
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToFloat64(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotRadiusVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Radius.SetTo(paramsDotRadiusVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Radius.Get(); ok {
+					if err := func() error {
+						if err := (validate.Float{
+							MinSet:        true,
+							Min:           0,
+							MaxSet:        true,
+							Max:           500,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    nil,
+							Pattern:       nil,
+						}).Validate(float64(value)); err != nil {
+							return errors.Wrap(err, "float")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "radius",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: limit.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "limit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLimitVal int
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLimitVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Limit.SetTo(paramsDotLimitVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Limit.Get(); ok {
+					if err := func() error {
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        true,
+							Max:           24,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+							Pattern:       nil,
+						}).Validate(int64(value)); err != nil {
+							return errors.Wrap(err, "int")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "limit",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: safe.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "safe",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSafeVal Safe
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSafeVal = Safe(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Safe.SetTo(paramsDotSafeVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Safe.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "safe",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: lang.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "lang",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLangVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLangVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Lang.SetTo(paramsDotLangVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "lang",
 			In:   "query",
 			Err:  err,
 		}
 	}
+	// Decode header: Accept-Language.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Accept-Language",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptLanguageVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptLanguageVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.AcceptLanguage.SetTo(paramsDotAcceptLanguageVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Accept-Language",
+			In:   "header",
+			Err:  err,
+		}
+	}
 	return params, nil
 }
@@ -68,6 +68,214 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			switch elem[0] {
+			case 'a': // Prefix: "admin/jobs"
+
+				if l := len("admin/jobs"); len(elem) >= l && elem[0:l] == "admin/jobs" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleGetJobsRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "id"
+					// Leaf parameter, slashes are prohibited
+					idx := strings.IndexByte(elem, '/')
+					if idx >= 0 {
+						break
+					}
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "GET":
+							s.handleGetJobRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+
+				}
+
+			case 'c': // Prefix: "countries/"
+
+				if l := len("countries/"); len(elem) >= l && elem[0:l] == "countries/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "code"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/wallpapers"
+
+					if l := len("/wallpapers"); len(elem) >= l && elem[0:l] == "/wallpapers" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "GET":
+							s.handleGetWallpapersByCountryRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+
+				}
+
+			case 'l': // Prefix: "landmarks/"
+
+				if l := len("landmarks/"); len(elem) >= l && elem[0:l] == "landmarks/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "slug"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/wallpapers"
+
+					if l := len("/wallpapers"); len(elem) >= l && elem[0:l] == "/wallpapers" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "GET":
+							s.handleGetWallpapersByLandmarkRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+
+				}
+
+			case 'p': // Prefix: "photographers"
+
+				if l := len("photographers"); len(elem) >= l && elem[0:l] == "photographers" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleGetPhotographersRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "slug"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/wallpapers"
+
+						if l := len("/wallpapers"); len(elem) >= l && elem[0:l] == "/wallpapers" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetWallpapersByPhotographerRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
+
+				}
+
 			case 'w': // Prefix: "wallpapers"
 
 				if l := len("wallpapers"); len(elem) >= l && elem[0:l] == "wallpapers" {
@@ -99,6 +307,27 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						break
 					}
 					switch elem[0] {
+					case 'n': // Prefix: "near"
+						origElem := elem
+						if l := len("near"); len(elem) >= l && elem[0:l] == "near" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetWallpapersNearRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+						elem = origElem
 					case 't': // Prefix: "tags"
 						origElem := elem
 						if l := len("tags"); len(elem) >= l && elem[0:l] == "tags" {
@@ -154,16 +383,15 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						elem = origElem
 					}
 					// Param: "id"
-					// Leaf parameter, slashes are prohibited
+					// Match until "/"
 					idx := strings.IndexByte(elem, '/')
-					if idx >= 0 {
-						break
+					if idx < 0 {
+						idx = len(elem)
 					}
-					args[0] = elem
-					elem = ""
+					args[0] = elem[:idx]
+					elem = elem[idx:]
 
 					if len(elem) == 0 {
-						// Leaf node.
 						switch r.Method {
 						case "GET":
 							s.handleGetWallpaperRequest([1]string{
@@ -175,6 +403,30 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 						return
 					}
+					switch elem[0] {
+					case '/': // Prefix: "/image"
+
+						if l := len("/image"); len(elem) >= l && elem[0:l] == "/image" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetWallpaperImageRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
 
 				}
 
@@ -290,6 +542,236 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 				}
 			}
 			switch elem[0] {
+			case 'a': // Prefix: "admin/jobs"
+
+				if l := len("admin/jobs"); len(elem) >= l && elem[0:l] == "admin/jobs" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = GetJobsOperation
+						r.summary = "Returns updater runs, latest first"
+						r.operationID = "getJobs"
+						r.operationGroup = ""
+						r.pathPattern = "/admin/jobs"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "id"
+					// Leaf parameter, slashes are prohibited
+					idx := strings.IndexByte(elem, '/')
+					if idx >= 0 {
+						break
+					}
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetJobOperation
+							r.summary = "Returns an updater run"
+							r.operationID = "getJob"
+							r.operationGroup = ""
+							r.pathPattern = "/admin/jobs/{id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+				}
+
+			case 'c': // Prefix: "countries/"
+
+				if l := len("countries/"); len(elem) >= l && elem[0:l] == "countries/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "code"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/wallpapers"
+
+					if l := len("/wallpapers"); len(elem) >= l && elem[0:l] == "/wallpapers" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetWallpapersByCountryOperation
+							r.summary = "Returns a list of wallpapers taken in the given country"
+							r.operationID = "getWallpapersByCountry"
+							r.operationGroup = ""
+							r.pathPattern = "/countries/{code}/wallpapers"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+				}
+
+			case 'l': // Prefix: "landmarks/"
+
+				if l := len("landmarks/"); len(elem) >= l && elem[0:l] == "landmarks/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				// Param: "slug"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/wallpapers"
+
+					if l := len("/wallpapers"); len(elem) >= l && elem[0:l] == "/wallpapers" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetWallpapersByLandmarkOperation
+							r.summary = "Returns a list of wallpapers showing the given landmark"
+							r.operationID = "getWallpapersByLandmark"
+							r.operationGroup = ""
+							r.pathPattern = "/landmarks/{slug}/wallpapers"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+				}
+
+			case 'p': // Prefix: "photographers"
+
+				if l := len("photographers"); len(elem) >= l && elem[0:l] == "photographers" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = GetPhotographersOperation
+						r.summary = "Returns photographers, most credited first"
+						r.operationID = "getPhotographers"
+						r.operationGroup = ""
+						r.pathPattern = "/photographers"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "slug"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/wallpapers"
+
+						if l := len("/wallpapers"); len(elem) >= l && elem[0:l] == "/wallpapers" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetWallpapersByPhotographerOperation
+								r.summary = "Returns a list of wallpapers by the given photographer"
+								r.operationID = "getWallpapersByPhotographer"
+								r.operationGroup = ""
+								r.pathPattern = "/photographers/{slug}/wallpapers"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+				}
+
 			case 'w': // Prefix: "wallpapers"
 
 				if l := len("wallpapers"); len(elem) >= l && elem[0:l] == "wallpapers" {
@@ -326,6 +808,32 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						break
 					}
 					switch elem[0] {
+					case 'n': // Prefix: "near"
+						origElem := elem
+						if l := len("near"); len(elem) >= l && elem[0:l] == "near" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetWallpapersNearOperation
+								r.summary = "Returns wallpapers taken near a location, nearest first"
+								r.operationID = "getWallpapersNear"
+								r.operationGroup = ""
+								r.pathPattern = "/wallpapers/near"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
 					case 't': // Prefix: "tags"
 						origElem := elem
 						if l := len("tags"); len(elem) >= l && elem[0:l] == "tags" {
@@ -389,16 +897,15 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						elem = origElem
 					}
 					// Param: "id"
-					// Leaf parameter, slashes are prohibited
+					// Match until "/"
 					idx := strings.IndexByte(elem, '/')
-					if idx >= 0 {
-						break
+					if idx < 0 {
+						idx = len(elem)
 					}
-					args[0] = elem
-					elem = ""
+					args[0] = elem[:idx]
+					elem = elem[idx:]
 
 					if len(elem) == 0 {
-						// Leaf node.
 						switch method {
 						case "GET":
 							r.name = GetWallpaperOperation
@@ -413,6 +920,33 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 							return
 						}
 					}
+					switch elem[0] {
+					case '/': // Prefix: "/image"
+
+						if l := len("/image"); len(elem) >= l && elem[0:l] == "/image" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetWallpaperImageOperation
+								r.summary = "Returns a wallpaper resized and converted"
+								r.operationID = "getWallpaperImage"
+								r.operationGroup = ""
+								r.pathPattern = "/wallpapers/{id}/image"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
 
 				}
 
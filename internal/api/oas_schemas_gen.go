@@ -2,11 +2,308 @@
 
 package api
 
+import (
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+type AdminAuth struct {
+	Token string
+	Roles []string
+}
+
+// GetToken returns the value of Token.
+func (s *AdminAuth) GetToken() string {
+	return s.Token
+}
+
+// GetRoles returns the value of Roles.
+func (s *AdminAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetToken sets the value of Token.
+func (s *AdminAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *AdminAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// Ref: #/components/schemas/Appearance
+type Appearance struct {
+	Market string `json:"market"`
+	Date   Date   `json:"date"`
+	// Title as published in the market.
+	Title OptString `json:"title"`
+	// Copyright as published in the market.
+	Copyright OptString `json:"copyright"`
+	// Headline as published in the market.
+	Headline OptString `json:"headline"`
+}
+
+// GetMarket returns the value of Market.
+func (s *Appearance) GetMarket() string {
+	return s.Market
+}
+
+// GetDate returns the value of Date.
+func (s *Appearance) GetDate() Date {
+	return s.Date
+}
+
+// GetTitle returns the value of Title.
+func (s *Appearance) GetTitle() OptString {
+	return s.Title
+}
+
+// GetCopyright returns the value of Copyright.
+func (s *Appearance) GetCopyright() OptString {
+	return s.Copyright
+}
+
+// GetHeadline returns the value of Headline.
+func (s *Appearance) GetHeadline() OptString {
+	return s.Headline
+}
+
+// SetMarket sets the value of Market.
+func (s *Appearance) SetMarket(val string) {
+	s.Market = val
+}
+
+// SetDate sets the value of Date.
+func (s *Appearance) SetDate(val Date) {
+	s.Date = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Appearance) SetTitle(val OptString) {
+	s.Title = val
+}
+
+// SetCopyright sets the value of Copyright.
+func (s *Appearance) SetCopyright(val OptString) {
+	s.Copyright = val
+}
+
+// SetHeadline sets the value of Headline.
+func (s *Appearance) SetHeadline(val OptString) {
+	s.Headline = val
+}
+
 type Date int
 
+// GetJobNotFound is response for GetJob operation.
+type GetJobNotFound struct{}
+
+func (*GetJobNotFound) getJobRes() {}
+
 // GetRootOK is response for GetRoot operation.
 type GetRootOK struct{}
 
+// GetWallpaperImageBadGateway is response for GetWallpaperImage operation.
+type GetWallpaperImageBadGateway struct{}
+
+func (*GetWallpaperImageBadGateway) getWallpaperImageRes() {}
+
+type GetWallpaperImageFit string
+
+const (
+	GetWallpaperImageFitContain GetWallpaperImageFit = "contain"
+	GetWallpaperImageFitCover   GetWallpaperImageFit = "cover"
+)
+
+// AllValues returns all GetWallpaperImageFit values.
+func (GetWallpaperImageFit) AllValues() []GetWallpaperImageFit {
+	return []GetWallpaperImageFit{
+		GetWallpaperImageFitContain,
+		GetWallpaperImageFitCover,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetWallpaperImageFit) MarshalText() ([]byte, error) {
+	switch s {
+	case GetWallpaperImageFitContain:
+		return []byte(s), nil
+	case GetWallpaperImageFitCover:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetWallpaperImageFit) UnmarshalText(data []byte) error {
+	switch GetWallpaperImageFit(data) {
+	case GetWallpaperImageFitContain:
+		*s = GetWallpaperImageFitContain
+		return nil
+	case GetWallpaperImageFitCover:
+		*s = GetWallpaperImageFitCover
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type GetWallpaperImageFormat string
+
+const (
+	GetWallpaperImageFormatJpeg GetWallpaperImageFormat = "jpeg"
+	GetWallpaperImageFormatPNG  GetWallpaperImageFormat = "png"
+)
+
+// AllValues returns all GetWallpaperImageFormat values.
+func (GetWallpaperImageFormat) AllValues() []GetWallpaperImageFormat {
+	return []GetWallpaperImageFormat{
+		GetWallpaperImageFormatJpeg,
+		GetWallpaperImageFormatPNG,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetWallpaperImageFormat) MarshalText() ([]byte, error) {
+	switch s {
+	case GetWallpaperImageFormatJpeg:
+		return []byte(s), nil
+	case GetWallpaperImageFormatPNG:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetWallpaperImageFormat) UnmarshalText(data []byte) error {
+	switch GetWallpaperImageFormat(data) {
+	case GetWallpaperImageFormatJpeg:
+		*s = GetWallpaperImageFormatJpeg
+		return nil
+	case GetWallpaperImageFormatPNG:
+		*s = GetWallpaperImageFormatPNG
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// GetWallpaperImageNotFound is response for GetWallpaperImage operation.
+type GetWallpaperImageNotFound struct{}
+
+func (*GetWallpaperImageNotFound) getWallpaperImageRes() {}
+
+type GetWallpaperImageOKImageJpeg struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetWallpaperImageOKImageJpeg) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// GetWallpaperImageOKImageJpegHeaders wraps GetWallpaperImageOKImageJpeg with response headers.
+type GetWallpaperImageOKImageJpegHeaders struct {
+	CacheControl OptString
+	ETag         OptString
+	Response     GetWallpaperImageOKImageJpeg
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *GetWallpaperImageOKImageJpegHeaders) GetCacheControl() OptString {
+	return s.CacheControl
+}
+
+// GetETag returns the value of ETag.
+func (s *GetWallpaperImageOKImageJpegHeaders) GetETag() OptString {
+	return s.ETag
+}
+
+// GetResponse returns the value of Response.
+func (s *GetWallpaperImageOKImageJpegHeaders) GetResponse() GetWallpaperImageOKImageJpeg {
+	return s.Response
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *GetWallpaperImageOKImageJpegHeaders) SetCacheControl(val OptString) {
+	s.CacheControl = val
+}
+
+// SetETag sets the value of ETag.
+func (s *GetWallpaperImageOKImageJpegHeaders) SetETag(val OptString) {
+	s.ETag = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetWallpaperImageOKImageJpegHeaders) SetResponse(val GetWallpaperImageOKImageJpeg) {
+	s.Response = val
+}
+
+func (*GetWallpaperImageOKImageJpegHeaders) getWallpaperImageRes() {}
+
+type GetWallpaperImageOKImagePNG struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetWallpaperImageOKImagePNG) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// GetWallpaperImageOKImagePNGHeaders wraps GetWallpaperImageOKImagePNG with response headers.
+type GetWallpaperImageOKImagePNGHeaders struct {
+	CacheControl OptString
+	ETag         OptString
+	Response     GetWallpaperImageOKImagePNG
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *GetWallpaperImageOKImagePNGHeaders) GetCacheControl() OptString {
+	return s.CacheControl
+}
+
+// GetETag returns the value of ETag.
+func (s *GetWallpaperImageOKImagePNGHeaders) GetETag() OptString {
+	return s.ETag
+}
+
+// GetResponse returns the value of Response.
+func (s *GetWallpaperImageOKImagePNGHeaders) GetResponse() GetWallpaperImageOKImagePNG {
+	return s.Response
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *GetWallpaperImageOKImagePNGHeaders) SetCacheControl(val OptString) {
+	s.CacheControl = val
+}
+
+// SetETag sets the value of ETag.
+func (s *GetWallpaperImageOKImagePNGHeaders) SetETag(val OptString) {
+	s.ETag = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetWallpaperImageOKImagePNGHeaders) SetResponse(val GetWallpaperImageOKImagePNG) {
+	s.Response = val
+}
+
+func (*GetWallpaperImageOKImagePNGHeaders) getWallpaperImageRes() {}
+
 // GetWallpaperNotFound is response for GetWallpaper operation.
 type GetWallpaperNotFound struct{}
 
@@ -30,16 +327,77 @@ func (s *GetWallpaperTagsOK) init() GetWallpaperTagsOK {
 
 func (*GetWallpaperTagsOK) getWallpaperTagsRes() {}
 
+// GetWallpapersByCountryNotFound is response for GetWallpapersByCountry operation.
+type GetWallpapersByCountryNotFound struct{}
+
+func (*GetWallpapersByCountryNotFound) getWallpapersByCountryRes() {}
+
+// GetWallpapersByLandmarkNotFound is response for GetWallpapersByLandmark operation.
+type GetWallpapersByLandmarkNotFound struct{}
+
+func (*GetWallpapersByLandmarkNotFound) getWallpapersByLandmarkRes() {}
+
+// GetWallpapersByPhotographerNotFound is response for GetWallpapersByPhotographer operation.
+type GetWallpapersByPhotographerNotFound struct{}
+
+func (*GetWallpapersByPhotographerNotFound) getWallpapersByPhotographerRes() {}
+
 // GetWallpapersByTagNotFound is response for GetWallpapersByTag operation.
 type GetWallpapersByTagNotFound struct{}
 
 func (*GetWallpapersByTagNotFound) getWallpapersByTagRes() {}
 
+// GetWallpapersNearNotFound is response for GetWallpapersNear operation.
+type GetWallpapersNearNotFound struct{}
+
+func (*GetWallpapersNearNotFound) getWallpapersNearRes() {}
+
 // GetWallpapersNotFound is response for GetWallpapers operation.
 type GetWallpapersNotFound struct{}
 
 func (*GetWallpapersNotFound) getWallpapersRes() {}
 
+type GetWallpapersOrientation string
+
+const (
+	GetWallpapersOrientationLandscape GetWallpapersOrientation = "landscape"
+	GetWallpapersOrientationPortrait  GetWallpapersOrientation = "portrait"
+)
+
+// AllValues returns all GetWallpapersOrientation values.
+func (GetWallpapersOrientation) AllValues() []GetWallpapersOrientation {
+	return []GetWallpapersOrientation{
+		GetWallpapersOrientationLandscape,
+		GetWallpapersOrientationPortrait,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetWallpapersOrientation) MarshalText() ([]byte, error) {
+	switch s {
+	case GetWallpapersOrientationLandscape:
+		return []byte(s), nil
+	case GetWallpapersOrientationPortrait:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetWallpapersOrientation) UnmarshalText(data []byte) error {
+	switch GetWallpapersOrientation(data) {
+	case GetWallpapersOrientationLandscape:
+		*s = GetWallpapersOrientationLandscape
+		return nil
+	case GetWallpapersOrientationPortrait:
+		*s = GetWallpapersOrientationPortrait
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 type GetWallpapersPrev int
 
 const (
@@ -53,66 +411,671 @@ func (GetWallpapersPrev) AllValues() []GetWallpapersPrev {
 	}
 }
 
-type ID string
+type ID string
+
+// An updater run.
+// Ref: #/components/schemas/Job
+type Job struct {
+	ID string `json:"id"`
+	// Operation, e.g. "update" or "backfill".
+	Type string `json:"type"`
+	// What started the run, e.g. "pubsub", "schedule" or "cli".
+	Trigger string `json:"trigger"`
+	// Partial if some images failed and the others were stored.
+	Status    JobStatus   `json:"status"`
+	StartedAt time.Time   `json:"startedAt"`
+	EndedAt   OptDateTime `json:"endedAt"`
+	// Number of wallpapers Bing listed per market.
+	Markets OptJobMarkets `json:"markets"`
+	New     []string      `json:"new"`
+	Updated []string      `json:"updated"`
+	Failed  []JobFailure  `json:"failed"`
+	// Why the run failed.
+	Error OptString `json:"error"`
+}
+
+// GetID returns the value of ID.
+func (s *Job) GetID() string {
+	return s.ID
+}
+
+// GetType returns the value of Type.
+func (s *Job) GetType() string {
+	return s.Type
+}
+
+// GetTrigger returns the value of Trigger.
+func (s *Job) GetTrigger() string {
+	return s.Trigger
+}
+
+// GetStatus returns the value of Status.
+func (s *Job) GetStatus() JobStatus {
+	return s.Status
+}
+
+// GetStartedAt returns the value of StartedAt.
+func (s *Job) GetStartedAt() time.Time {
+	return s.StartedAt
+}
+
+// GetEndedAt returns the value of EndedAt.
+func (s *Job) GetEndedAt() OptDateTime {
+	return s.EndedAt
+}
+
+// GetMarkets returns the value of Markets.
+func (s *Job) GetMarkets() OptJobMarkets {
+	return s.Markets
+}
+
+// GetNew returns the value of New.
+func (s *Job) GetNew() []string {
+	return s.New
+}
+
+// GetUpdated returns the value of Updated.
+func (s *Job) GetUpdated() []string {
+	return s.Updated
+}
+
+// GetFailed returns the value of Failed.
+func (s *Job) GetFailed() []JobFailure {
+	return s.Failed
+}
+
+// GetError returns the value of Error.
+func (s *Job) GetError() OptString {
+	return s.Error
+}
+
+// SetID sets the value of ID.
+func (s *Job) SetID(val string) {
+	s.ID = val
+}
+
+// SetType sets the value of Type.
+func (s *Job) SetType(val string) {
+	s.Type = val
+}
+
+// SetTrigger sets the value of Trigger.
+func (s *Job) SetTrigger(val string) {
+	s.Trigger = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Job) SetStatus(val JobStatus) {
+	s.Status = val
+}
+
+// SetStartedAt sets the value of StartedAt.
+func (s *Job) SetStartedAt(val time.Time) {
+	s.StartedAt = val
+}
+
+// SetEndedAt sets the value of EndedAt.
+func (s *Job) SetEndedAt(val OptDateTime) {
+	s.EndedAt = val
+}
+
+// SetMarkets sets the value of Markets.
+func (s *Job) SetMarkets(val OptJobMarkets) {
+	s.Markets = val
+}
+
+// SetNew sets the value of New.
+func (s *Job) SetNew(val []string) {
+	s.New = val
+}
+
+// SetUpdated sets the value of Updated.
+func (s *Job) SetUpdated(val []string) {
+	s.Updated = val
+}
+
+// SetFailed sets the value of Failed.
+func (s *Job) SetFailed(val []JobFailure) {
+	s.Failed = val
+}
+
+// SetError sets the value of Error.
+func (s *Job) SetError(val OptString) {
+	s.Error = val
+}
+
+func (*Job) getJobRes() {}
+
+// An image a run failed to store.
+// Ref: #/components/schemas/JobFailure
+type JobFailure struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// GetID returns the value of ID.
+func (s *JobFailure) GetID() string {
+	return s.ID
+}
+
+// GetError returns the value of Error.
+func (s *JobFailure) GetError() string {
+	return s.Error
+}
+
+// SetID sets the value of ID.
+func (s *JobFailure) SetID(val string) {
+	s.ID = val
+}
+
+// SetError sets the value of Error.
+func (s *JobFailure) SetError(val string) {
+	s.Error = val
+}
+
+// Number of wallpapers Bing listed per market.
+type JobMarkets map[string]int
+
+func (s *JobMarkets) init() JobMarkets {
+	m := *s
+	if m == nil {
+		m = map[string]int{}
+		*s = m
+	}
+	return m
+}
+
+// Partial if some images failed and the others were stored.
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusPartial   JobStatus = "partial"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// AllValues returns all JobStatus values.
+func (JobStatus) AllValues() []JobStatus {
+	return []JobStatus{
+		JobStatusRunning,
+		JobStatusSucceeded,
+		JobStatusPartial,
+		JobStatusFailed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s JobStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case JobStatusRunning:
+		return []byte(s), nil
+	case JobStatusSucceeded:
+		return []byte(s), nil
+	case JobStatusPartial:
+		return []byte(s), nil
+	case JobStatusFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *JobStatus) UnmarshalText(data []byte) error {
+	switch JobStatus(data) {
+	case JobStatusRunning:
+		*s = JobStatusRunning
+		return nil
+	case JobStatusSucceeded:
+		*s = JobStatusSucceeded
+		return nil
+	case JobStatusPartial:
+		*s = JobStatusPartial
+		return nil
+	case JobStatusFailed:
+		*s = JobStatusFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/Landmark
+type Landmark struct {
+	Name  string     `json:"name"`
+	Score float32    `json:"score"`
+	Lat   OptFloat64 `json:"lat"`
+	Lng   OptFloat64 `json:"lng"`
+}
+
+// GetName returns the value of Name.
+func (s *Landmark) GetName() string {
+	return s.Name
+}
+
+// GetScore returns the value of Score.
+func (s *Landmark) GetScore() float32 {
+	return s.Score
+}
+
+// GetLat returns the value of Lat.
+func (s *Landmark) GetLat() OptFloat64 {
+	return s.Lat
+}
+
+// GetLng returns the value of Lng.
+func (s *Landmark) GetLng() OptFloat64 {
+	return s.Lng
+}
+
+// SetName sets the value of Name.
+func (s *Landmark) SetName(val string) {
+	s.Name = val
+}
+
+// SetScore sets the value of Score.
+func (s *Landmark) SetScore(val float32) {
+	s.Score = val
+}
+
+// SetLat sets the value of Lat.
+func (s *Landmark) SetLat(val OptFloat64) {
+	s.Lat = val
+}
+
+// SetLng sets the value of Lng.
+func (s *Landmark) SetLng(val OptFloat64) {
+	s.Lng = val
+}
+
+// Ref: #/components/schemas/Links
+type Links struct {
+	Prev OptString `json:"prev"`
+	Next OptString `json:"next"`
+}
+
+// GetPrev returns the value of Prev.
+func (s *Links) GetPrev() OptString {
+	return s.Prev
+}
+
+// GetNext returns the value of Next.
+func (s *Links) GetNext() OptString {
+	return s.Next
+}
+
+// SetPrev sets the value of Prev.
+func (s *Links) SetPrev(val OptString) {
+	s.Prev = val
+}
+
+// SetNext sets the value of Next.
+func (s *Links) SetNext(val OptString) {
+	s.Next = val
+}
+
+// Ref: #/components/schemas/Localization
+type Localization struct {
+	Title             string    `json:"title"`
+	Copyright         OptString `json:"copyright"`
+	Headline          OptString `json:"headline"`
+	MachineTranslated OptBool   `json:"machineTranslated"`
+}
+
+// GetTitle returns the value of Title.
+func (s *Localization) GetTitle() string {
+	return s.Title
+}
+
+// GetCopyright returns the value of Copyright.
+func (s *Localization) GetCopyright() OptString {
+	return s.Copyright
+}
+
+// GetHeadline returns the value of Headline.
+func (s *Localization) GetHeadline() OptString {
+	return s.Headline
+}
+
+// GetMachineTranslated returns the value of MachineTranslated.
+func (s *Localization) GetMachineTranslated() OptBool {
+	return s.MachineTranslated
+}
+
+// SetTitle sets the value of Title.
+func (s *Localization) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetCopyright sets the value of Copyright.
+func (s *Localization) SetCopyright(val OptString) {
+	s.Copyright = val
+}
+
+// SetHeadline sets the value of Headline.
+func (s *Localization) SetHeadline(val OptString) {
+	s.Headline = val
+}
+
+// SetMachineTranslated sets the value of MachineTranslated.
+func (s *Localization) SetMachineTranslated(val OptBool) {
+	s.MachineTranslated = val
+}
+
+// Where the wallpaper was taken.
+// Ref: #/components/schemas/Location
+type Location struct {
+	// Name of the landmark or place the location was found from.
+	Place string  `json:"place"`
+	Lat   float64 `json:"lat"`
+	Lng   float64 `json:"lng"`
+	// ISO 3166-1 alpha-2 country code.
+	Country OptString `json:"country"`
+}
+
+// GetPlace returns the value of Place.
+func (s *Location) GetPlace() string {
+	return s.Place
+}
+
+// GetLat returns the value of Lat.
+func (s *Location) GetLat() float64 {
+	return s.Lat
+}
+
+// GetLng returns the value of Lng.
+func (s *Location) GetLng() float64 {
+	return s.Lng
+}
+
+// GetCountry returns the value of Country.
+func (s *Location) GetCountry() OptString {
+	return s.Country
+}
+
+// SetPlace sets the value of Place.
+func (s *Location) SetPlace(val string) {
+	s.Place = val
+}
+
+// SetLat sets the value of Lat.
+func (s *Location) SetLat(val float64) {
+	s.Lat = val
+}
+
+// SetLng sets the value of Lng.
+func (s *Location) SetLng(val float64) {
+	s.Lng = val
+}
+
+// SetCountry sets the value of Country.
+func (s *Location) SetCountry(val OptString) {
+	s.Country = val
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v Date) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional Date.
+type OptDate struct {
+	Value Date
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v Date
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v Date) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v Date, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d Date) Date {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptGetWallpaperImageFit returns new OptGetWallpaperImageFit with value set to v.
+func NewOptGetWallpaperImageFit(v GetWallpaperImageFit) OptGetWallpaperImageFit {
+	return OptGetWallpaperImageFit{
+		Value: v,
+		Set:   true,
+	}
+}
 
-// Ref: #/components/schemas/Links
-type Links struct {
-	Prev OptString `json:"prev"`
-	Next OptString `json:"next"`
+// OptGetWallpaperImageFit is optional GetWallpaperImageFit.
+type OptGetWallpaperImageFit struct {
+	Value GetWallpaperImageFit
+	Set   bool
 }
 
-// GetPrev returns the value of Prev.
-func (s *Links) GetPrev() OptString {
-	return s.Prev
+// IsSet returns true if OptGetWallpaperImageFit was set.
+func (o OptGetWallpaperImageFit) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptGetWallpaperImageFit) Reset() {
+	var v GetWallpaperImageFit
+	o.Value = v
+	o.Set = false
 }
 
-// GetNext returns the value of Next.
-func (s *Links) GetNext() OptString {
-	return s.Next
+// SetTo sets value to v.
+func (o *OptGetWallpaperImageFit) SetTo(v GetWallpaperImageFit) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetPrev sets the value of Prev.
-func (s *Links) SetPrev(val OptString) {
-	s.Prev = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetWallpaperImageFit) Get() (v GetWallpaperImageFit, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetNext sets the value of Next.
-func (s *Links) SetNext(val OptString) {
-	s.Next = val
+// Or returns value if set, or given parameter if does not.
+func (o OptGetWallpaperImageFit) Or(d GetWallpaperImageFit) GetWallpaperImageFit {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// NewOptDate returns new OptDate with value set to v.
-func NewOptDate(v Date) OptDate {
-	return OptDate{
+// NewOptGetWallpaperImageFormat returns new OptGetWallpaperImageFormat with value set to v.
+func NewOptGetWallpaperImageFormat(v GetWallpaperImageFormat) OptGetWallpaperImageFormat {
+	return OptGetWallpaperImageFormat{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptDate is optional Date.
-type OptDate struct {
-	Value Date
+// OptGetWallpaperImageFormat is optional GetWallpaperImageFormat.
+type OptGetWallpaperImageFormat struct {
+	Value GetWallpaperImageFormat
 	Set   bool
 }
 
-// IsSet returns true if OptDate was set.
-func (o OptDate) IsSet() bool { return o.Set }
+// IsSet returns true if OptGetWallpaperImageFormat was set.
+func (o OptGetWallpaperImageFormat) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptDate) Reset() {
-	var v Date
+func (o *OptGetWallpaperImageFormat) Reset() {
+	var v GetWallpaperImageFormat
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptDate) SetTo(v Date) {
+func (o *OptGetWallpaperImageFormat) SetTo(v GetWallpaperImageFormat) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptDate) Get() (v Date, ok bool) {
+func (o OptGetWallpaperImageFormat) Get() (v GetWallpaperImageFormat, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -120,45 +1083,45 @@ func (o OptDate) Get() (v Date, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptDate) Or(d Date) Date {
+func (o OptGetWallpaperImageFormat) Or(d GetWallpaperImageFormat) GetWallpaperImageFormat {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptFloat64 returns new OptFloat64 with value set to v.
-func NewOptFloat64(v float64) OptFloat64 {
-	return OptFloat64{
+// NewOptGetWallpapersOrientation returns new OptGetWallpapersOrientation with value set to v.
+func NewOptGetWallpapersOrientation(v GetWallpapersOrientation) OptGetWallpapersOrientation {
+	return OptGetWallpapersOrientation{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptFloat64 is optional float64.
-type OptFloat64 struct {
-	Value float64
+// OptGetWallpapersOrientation is optional GetWallpapersOrientation.
+type OptGetWallpapersOrientation struct {
+	Value GetWallpapersOrientation
 	Set   bool
 }
 
-// IsSet returns true if OptFloat64 was set.
-func (o OptFloat64) IsSet() bool { return o.Set }
+// IsSet returns true if OptGetWallpapersOrientation was set.
+func (o OptGetWallpapersOrientation) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptFloat64) Reset() {
-	var v float64
+func (o *OptGetWallpapersOrientation) Reset() {
+	var v GetWallpapersOrientation
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptFloat64) SetTo(v float64) {
+func (o *OptGetWallpapersOrientation) SetTo(v GetWallpapersOrientation) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptFloat64) Get() (v float64, ok bool) {
+func (o OptGetWallpapersOrientation) Get() (v GetWallpapersOrientation, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -166,7 +1129,7 @@ func (o OptFloat64) Get() (v float64, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptFloat64) Or(d float64) float64 {
+func (o OptGetWallpapersOrientation) Or(d GetWallpapersOrientation) GetWallpapersOrientation {
 	if v, ok := o.Get(); ok {
 		return v
 	}
@@ -237,20 +1200,250 @@ type OptID struct {
 func (o OptID) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptID) Reset() {
-	var v ID
+func (o *OptID) Reset() {
+	var v ID
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptID) SetTo(v ID) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptID) Get() (v ID, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptID) Or(d ID) ID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptJobMarkets returns new OptJobMarkets with value set to v.
+func NewOptJobMarkets(v JobMarkets) OptJobMarkets {
+	return OptJobMarkets{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptJobMarkets is optional JobMarkets.
+type OptJobMarkets struct {
+	Value JobMarkets
+	Set   bool
+}
+
+// IsSet returns true if OptJobMarkets was set.
+func (o OptJobMarkets) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptJobMarkets) Reset() {
+	var v JobMarkets
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptJobMarkets) SetTo(v JobMarkets) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptJobMarkets) Get() (v JobMarkets, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptJobMarkets) Or(d JobMarkets) JobMarkets {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptLocation returns new OptLocation with value set to v.
+func NewOptLocation(v Location) OptLocation {
+	return OptLocation{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptLocation is optional Location.
+type OptLocation struct {
+	Value Location
+	Set   bool
+}
+
+// IsSet returns true if OptLocation was set.
+func (o OptLocation) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptLocation) Reset() {
+	var v Location
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptLocation) SetTo(v Location) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptLocation) Get() (v Location, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptLocation) Or(d Location) Location {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSafe returns new OptSafe with value set to v.
+func NewOptSafe(v Safe) OptSafe {
+	return OptSafe{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSafe is optional Safe.
+type OptSafe struct {
+	Value Safe
+	Set   bool
+}
+
+// IsSet returns true if OptSafe was set.
+func (o OptSafe) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSafe) Reset() {
+	var v Safe
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSafe) SetTo(v Safe) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSafe) Get() (v Safe, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSafe) Or(d Safe) Safe {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSafeSearch returns new OptSafeSearch with value set to v.
+func NewOptSafeSearch(v SafeSearch) OptSafeSearch {
+	return OptSafeSearch{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSafeSearch is optional SafeSearch.
+type OptSafeSearch struct {
+	Value SafeSearch
+	Set   bool
+}
+
+// IsSet returns true if OptSafeSearch was set.
+func (o OptSafeSearch) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSafeSearch) Reset() {
+	var v SafeSearch
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptID) SetTo(v ID) {
+func (o *OptSafeSearch) SetTo(v SafeSearch) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptID) Get() (v ID, ok bool) {
+func (o OptSafeSearch) Get() (v SafeSearch, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -258,45 +1451,45 @@ func (o OptID) Get() (v ID, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptID) Or(d ID) ID {
+func (o OptSafeSearch) Or(d SafeSearch) SafeSearch {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptInt returns new OptInt with value set to v.
-func NewOptInt(v int) OptInt {
-	return OptInt{
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptInt is optional int.
-type OptInt struct {
-	Value int
+// OptString is optional string.
+type OptString struct {
+	Value string
 	Set   bool
 }
 
-// IsSet returns true if OptInt was set.
-func (o OptInt) IsSet() bool { return o.Set }
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptInt) Reset() {
-	var v int
+func (o *OptString) Reset() {
+	var v string
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptInt) SetTo(v int) {
+func (o *OptString) SetTo(v string) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptInt) Get() (v int, ok bool) {
+func (o OptString) Get() (v string, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -304,45 +1497,45 @@ func (o OptInt) Get() (v int, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptInt) Or(d int) int {
+func (o OptString) Or(d string) string {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
+// NewOptWallpaperWithTagsLocalized returns new OptWallpaperWithTagsLocalized with value set to v.
+func NewOptWallpaperWithTagsLocalized(v WallpaperWithTagsLocalized) OptWallpaperWithTagsLocalized {
+	return OptWallpaperWithTagsLocalized{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
+// OptWallpaperWithTagsLocalized is optional WallpaperWithTagsLocalized.
+type OptWallpaperWithTagsLocalized struct {
+	Value WallpaperWithTagsLocalized
 	Set   bool
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// IsSet returns true if OptWallpaperWithTagsLocalized was set.
+func (o OptWallpaperWithTagsLocalized) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
+func (o *OptWallpaperWithTagsLocalized) Reset() {
+	var v WallpaperWithTagsLocalized
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
+func (o *OptWallpaperWithTagsLocalized) SetTo(v WallpaperWithTagsLocalized) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
+func (o OptWallpaperWithTagsLocalized) Get() (v WallpaperWithTagsLocalized, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -350,13 +1543,136 @@ func (o OptString) Get() (v string, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
+func (o OptWallpaperWithTagsLocalized) Or(d WallpaperWithTagsLocalized) WallpaperWithTagsLocalized {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
+// Ref: #/components/schemas/Photographer
+type Photographer struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// Agency of the photographer's latest wallpaper.
+	Agency OptString `json:"agency"`
+	// Number of wallpapers credited to the photographer.
+	Count int `json:"count"`
+}
+
+// GetSlug returns the value of Slug.
+func (s *Photographer) GetSlug() string {
+	return s.Slug
+}
+
+// GetName returns the value of Name.
+func (s *Photographer) GetName() string {
+	return s.Name
+}
+
+// GetAgency returns the value of Agency.
+func (s *Photographer) GetAgency() OptString {
+	return s.Agency
+}
+
+// GetCount returns the value of Count.
+func (s *Photographer) GetCount() int {
+	return s.Count
+}
+
+// SetSlug sets the value of Slug.
+func (s *Photographer) SetSlug(val string) {
+	s.Slug = val
+}
+
+// SetName sets the value of Name.
+func (s *Photographer) SetName(val string) {
+	s.Name = val
+}
+
+// SetAgency sets the value of Agency.
+func (s *Photographer) SetAgency(val OptString) {
+	s.Agency = val
+}
+
+// SetCount sets the value of Count.
+func (s *Photographer) SetCount(val int) {
+	s.Count = val
+}
+
+type Safe int
+
+const (
+	Safe1 Safe = 1
+)
+
+// AllValues returns all Safe values.
+func (Safe) AllValues() []Safe {
+	return []Safe{
+		Safe1,
+	}
+}
+
+// Likelihood of each kind of sensitive content, from VERY_UNLIKELY to VERY_LIKELY.
+// Ref: #/components/schemas/SafeSearch
+type SafeSearch struct {
+	Adult    string `json:"adult"`
+	Spoof    string `json:"spoof"`
+	Medical  string `json:"medical"`
+	Violence string `json:"violence"`
+	Racy     string `json:"racy"`
+}
+
+// GetAdult returns the value of Adult.
+func (s *SafeSearch) GetAdult() string {
+	return s.Adult
+}
+
+// GetSpoof returns the value of Spoof.
+func (s *SafeSearch) GetSpoof() string {
+	return s.Spoof
+}
+
+// GetMedical returns the value of Medical.
+func (s *SafeSearch) GetMedical() string {
+	return s.Medical
+}
+
+// GetViolence returns the value of Violence.
+func (s *SafeSearch) GetViolence() string {
+	return s.Violence
+}
+
+// GetRacy returns the value of Racy.
+func (s *SafeSearch) GetRacy() string {
+	return s.Racy
+}
+
+// SetAdult sets the value of Adult.
+func (s *SafeSearch) SetAdult(val string) {
+	s.Adult = val
+}
+
+// SetSpoof sets the value of Spoof.
+func (s *SafeSearch) SetSpoof(val string) {
+	s.Spoof = val
+}
+
+// SetMedical sets the value of Medical.
+func (s *SafeSearch) SetMedical(val string) {
+	s.Medical = val
+}
+
+// SetViolence sets the value of Violence.
+func (s *SafeSearch) SetViolence(val string) {
+	s.Violence = val
+}
+
+// SetRacy sets the value of Racy.
+func (s *SafeSearch) SetRacy(val string) {
+	s.Racy = val
+}
+
 // Ref: #/components/schemas/Wallpaper
 type Wallpaper struct {
 	ID        ID     `json:"id"`
@@ -367,6 +1683,26 @@ type Wallpaper struct {
 	UrlBase   string `json:"urlBase"`
 	// Dominant colors as hex strings (e.g., "#4A90D9").
 	Colors []string `json:"colors"`
+	// Language of the title and copyright.
+	Lang OptString `json:"lang"`
+	// Whether the title is a machine translation.
+	MachineTranslated OptBool     `json:"machineTranslated"`
+	Location          OptLocation `json:"location"`
+	// Photographer parsed from the copyright.
+	Photographer     OptString `json:"photographer"`
+	PhotographerSlug OptString `json:"photographerSlug"`
+	// Agency parsed from the copyright.
+	Agency OptString `json:"agency"`
+	// Headline Bing published with the wallpaper (e.g. "Happy Presidents Day!").
+	Headline OptString `json:"headline"`
+	// Bing search about the wallpaper.
+	SearchUrl OptString `json:"searchUrl"`
+	// Resolution variants, largest first.
+	Images []WallpaperImage `json:"images"`
+	// BlurHash to show while the image loads.
+	BlurHash OptString `json:"blurHash"`
+	// Data URI of a tiny JPEG preview to show while the image loads.
+	Lqip OptString `json:"lqip"`
 }
 
 // GetID returns the value of ID.
@@ -404,6 +1740,61 @@ func (s *Wallpaper) GetColors() []string {
 	return s.Colors
 }
 
+// GetLang returns the value of Lang.
+func (s *Wallpaper) GetLang() OptString {
+	return s.Lang
+}
+
+// GetMachineTranslated returns the value of MachineTranslated.
+func (s *Wallpaper) GetMachineTranslated() OptBool {
+	return s.MachineTranslated
+}
+
+// GetLocation returns the value of Location.
+func (s *Wallpaper) GetLocation() OptLocation {
+	return s.Location
+}
+
+// GetPhotographer returns the value of Photographer.
+func (s *Wallpaper) GetPhotographer() OptString {
+	return s.Photographer
+}
+
+// GetPhotographerSlug returns the value of PhotographerSlug.
+func (s *Wallpaper) GetPhotographerSlug() OptString {
+	return s.PhotographerSlug
+}
+
+// GetAgency returns the value of Agency.
+func (s *Wallpaper) GetAgency() OptString {
+	return s.Agency
+}
+
+// GetHeadline returns the value of Headline.
+func (s *Wallpaper) GetHeadline() OptString {
+	return s.Headline
+}
+
+// GetSearchUrl returns the value of SearchUrl.
+func (s *Wallpaper) GetSearchUrl() OptString {
+	return s.SearchUrl
+}
+
+// GetImages returns the value of Images.
+func (s *Wallpaper) GetImages() []WallpaperImage {
+	return s.Images
+}
+
+// GetBlurHash returns the value of BlurHash.
+func (s *Wallpaper) GetBlurHash() OptString {
+	return s.BlurHash
+}
+
+// GetLqip returns the value of Lqip.
+func (s *Wallpaper) GetLqip() OptString {
+	return s.Lqip
+}
+
 // SetID sets the value of ID.
 func (s *Wallpaper) SetID(val ID) {
 	s.ID = val
@@ -439,6 +1830,170 @@ func (s *Wallpaper) SetColors(val []string) {
 	s.Colors = val
 }
 
+// SetLang sets the value of Lang.
+func (s *Wallpaper) SetLang(val OptString) {
+	s.Lang = val
+}
+
+// SetMachineTranslated sets the value of MachineTranslated.
+func (s *Wallpaper) SetMachineTranslated(val OptBool) {
+	s.MachineTranslated = val
+}
+
+// SetLocation sets the value of Location.
+func (s *Wallpaper) SetLocation(val OptLocation) {
+	s.Location = val
+}
+
+// SetPhotographer sets the value of Photographer.
+func (s *Wallpaper) SetPhotographer(val OptString) {
+	s.Photographer = val
+}
+
+// SetPhotographerSlug sets the value of PhotographerSlug.
+func (s *Wallpaper) SetPhotographerSlug(val OptString) {
+	s.PhotographerSlug = val
+}
+
+// SetAgency sets the value of Agency.
+func (s *Wallpaper) SetAgency(val OptString) {
+	s.Agency = val
+}
+
+// SetHeadline sets the value of Headline.
+func (s *Wallpaper) SetHeadline(val OptString) {
+	s.Headline = val
+}
+
+// SetSearchUrl sets the value of SearchUrl.
+func (s *Wallpaper) SetSearchUrl(val OptString) {
+	s.SearchUrl = val
+}
+
+// SetImages sets the value of Images.
+func (s *Wallpaper) SetImages(val []WallpaperImage) {
+	s.Images = val
+}
+
+// SetBlurHash sets the value of BlurHash.
+func (s *Wallpaper) SetBlurHash(val OptString) {
+	s.BlurHash = val
+}
+
+// SetLqip sets the value of Lqip.
+func (s *Wallpaper) SetLqip(val OptString) {
+	s.Lqip = val
+}
+
+// Ref: #/components/schemas/WallpaperImage
+type WallpaperImage struct {
+	// E.g. "UHD" or "1920x1200".
+	Resolution string `json:"resolution"`
+	// Our mirrored copy if there is one, otherwise Bing's.
+	URL      string `json:"url"`
+	Mirrored bool   `json:"mirrored"`
+	// Hex encoded checksum of the mirrored copy.
+	SHA256 OptString `json:"sha256"`
+	// Bytes.
+	Size   OptInt `json:"size"`
+	Width  OptInt `json:"width"`
+	Height OptInt `json:"height"`
+	// Width divided by height.
+	AspectRatio OptFloat64 `json:"aspectRatio"`
+	// Estimated JPEG quality (1-100).
+	Quality OptInt `json:"quality"`
+}
+
+// GetResolution returns the value of Resolution.
+func (s *WallpaperImage) GetResolution() string {
+	return s.Resolution
+}
+
+// GetURL returns the value of URL.
+func (s *WallpaperImage) GetURL() string {
+	return s.URL
+}
+
+// GetMirrored returns the value of Mirrored.
+func (s *WallpaperImage) GetMirrored() bool {
+	return s.Mirrored
+}
+
+// GetSHA256 returns the value of SHA256.
+func (s *WallpaperImage) GetSHA256() OptString {
+	return s.SHA256
+}
+
+// GetSize returns the value of Size.
+func (s *WallpaperImage) GetSize() OptInt {
+	return s.Size
+}
+
+// GetWidth returns the value of Width.
+func (s *WallpaperImage) GetWidth() OptInt {
+	return s.Width
+}
+
+// GetHeight returns the value of Height.
+func (s *WallpaperImage) GetHeight() OptInt {
+	return s.Height
+}
+
+// GetAspectRatio returns the value of AspectRatio.
+func (s *WallpaperImage) GetAspectRatio() OptFloat64 {
+	return s.AspectRatio
+}
+
+// GetQuality returns the value of Quality.
+func (s *WallpaperImage) GetQuality() OptInt {
+	return s.Quality
+}
+
+// SetResolution sets the value of Resolution.
+func (s *WallpaperImage) SetResolution(val string) {
+	s.Resolution = val
+}
+
+// SetURL sets the value of URL.
+func (s *WallpaperImage) SetURL(val string) {
+	s.URL = val
+}
+
+// SetMirrored sets the value of Mirrored.
+func (s *WallpaperImage) SetMirrored(val bool) {
+	s.Mirrored = val
+}
+
+// SetSHA256 sets the value of SHA256.
+func (s *WallpaperImage) SetSHA256(val OptString) {
+	s.SHA256 = val
+}
+
+// SetSize sets the value of Size.
+func (s *WallpaperImage) SetSize(val OptInt) {
+	s.Size = val
+}
+
+// SetWidth sets the value of Width.
+func (s *WallpaperImage) SetWidth(val OptInt) {
+	s.Width = val
+}
+
+// SetHeight sets the value of Height.
+func (s *WallpaperImage) SetHeight(val OptInt) {
+	s.Height = val
+}
+
+// SetAspectRatio sets the value of AspectRatio.
+func (s *WallpaperImage) SetAspectRatio(val OptFloat64) {
+	s.AspectRatio = val
+}
+
+// SetQuality sets the value of Quality.
+func (s *WallpaperImage) SetQuality(val OptInt) {
+	s.Quality = val
+}
+
 // Ref: #/components/schemas/WallpaperList
 type WallpaperList struct {
 	Data  []Wallpaper `json:"data"`
@@ -465,8 +2020,12 @@ func (s *WallpaperList) SetLinks(val Links) {
 	s.Links = val
 }
 
-func (*WallpaperList) getWallpapersByTagRes() {}
-func (*WallpaperList) getWallpapersRes()      {}
+func (*WallpaperList) getWallpapersByCountryRes()      {}
+func (*WallpaperList) getWallpapersByLandmarkRes()     {}
+func (*WallpaperList) getWallpapersByPhotographerRes() {}
+func (*WallpaperList) getWallpapersByTagRes()          {}
+func (*WallpaperList) getWallpapersNearRes()           {}
+func (*WallpaperList) getWallpapersRes()               {}
 
 // Merged schema.
 // Ref: #/components/schemas/WallpaperWithTags
@@ -478,8 +2037,35 @@ type WallpaperWithTags struct {
 	Market    string `json:"market"`
 	UrlBase   string `json:"urlBase"`
 	// Dominant colors as hex strings (e.g., "#4A90D9").
-	Colors []string              `json:"colors"`
-	Tags   WallpaperWithTagsTags `json:"tags"`
+	Colors []string `json:"colors"`
+	// Language of the title and copyright.
+	Lang OptString `json:"lang"`
+	// Whether the title is a machine translation.
+	MachineTranslated OptBool     `json:"machineTranslated"`
+	Location          OptLocation `json:"location"`
+	// Photographer parsed from the copyright.
+	Photographer     OptString `json:"photographer"`
+	PhotographerSlug OptString `json:"photographerSlug"`
+	// Agency parsed from the copyright.
+	Agency OptString `json:"agency"`
+	// Headline Bing published with the wallpaper (e.g. "Happy Presidents Day!").
+	Headline OptString `json:"headline"`
+	// Bing search about the wallpaper.
+	SearchUrl OptString `json:"searchUrl"`
+	// Resolution variants, largest first.
+	Images []WallpaperImage `json:"images"`
+	// BlurHash to show while the image loads.
+	BlurHash OptString `json:"blurHash"`
+	// Data URI of a tiny JPEG preview to show while the image loads.
+	Lqip OptString             `json:"lqip"`
+	Tags WallpaperWithTagsTags `json:"tags"`
+	// Every market and date the wallpaper appeared in.
+	Appearances []Appearance `json:"appearances"`
+	// Titles and copyrights keyed by language.
+	Localized   OptWallpaperWithTagsLocalized `json:"localized"`
+	Landmarks   []Landmark                    `json:"landmarks"`
+	WebEntities []WebEntity                   `json:"webEntities"`
+	SafeSearch  OptSafeSearch                 `json:"safeSearch"`
 }
 
 // GetID returns the value of ID.
@@ -517,11 +2103,91 @@ func (s *WallpaperWithTags) GetColors() []string {
 	return s.Colors
 }
 
+// GetLang returns the value of Lang.
+func (s *WallpaperWithTags) GetLang() OptString {
+	return s.Lang
+}
+
+// GetMachineTranslated returns the value of MachineTranslated.
+func (s *WallpaperWithTags) GetMachineTranslated() OptBool {
+	return s.MachineTranslated
+}
+
+// GetLocation returns the value of Location.
+func (s *WallpaperWithTags) GetLocation() OptLocation {
+	return s.Location
+}
+
+// GetPhotographer returns the value of Photographer.
+func (s *WallpaperWithTags) GetPhotographer() OptString {
+	return s.Photographer
+}
+
+// GetPhotographerSlug returns the value of PhotographerSlug.
+func (s *WallpaperWithTags) GetPhotographerSlug() OptString {
+	return s.PhotographerSlug
+}
+
+// GetAgency returns the value of Agency.
+func (s *WallpaperWithTags) GetAgency() OptString {
+	return s.Agency
+}
+
+// GetHeadline returns the value of Headline.
+func (s *WallpaperWithTags) GetHeadline() OptString {
+	return s.Headline
+}
+
+// GetSearchUrl returns the value of SearchUrl.
+func (s *WallpaperWithTags) GetSearchUrl() OptString {
+	return s.SearchUrl
+}
+
+// GetImages returns the value of Images.
+func (s *WallpaperWithTags) GetImages() []WallpaperImage {
+	return s.Images
+}
+
+// GetBlurHash returns the value of BlurHash.
+func (s *WallpaperWithTags) GetBlurHash() OptString {
+	return s.BlurHash
+}
+
+// GetLqip returns the value of Lqip.
+func (s *WallpaperWithTags) GetLqip() OptString {
+	return s.Lqip
+}
+
 // GetTags returns the value of Tags.
 func (s *WallpaperWithTags) GetTags() WallpaperWithTagsTags {
 	return s.Tags
 }
 
+// GetAppearances returns the value of Appearances.
+func (s *WallpaperWithTags) GetAppearances() []Appearance {
+	return s.Appearances
+}
+
+// GetLocalized returns the value of Localized.
+func (s *WallpaperWithTags) GetLocalized() OptWallpaperWithTagsLocalized {
+	return s.Localized
+}
+
+// GetLandmarks returns the value of Landmarks.
+func (s *WallpaperWithTags) GetLandmarks() []Landmark {
+	return s.Landmarks
+}
+
+// GetWebEntities returns the value of WebEntities.
+func (s *WallpaperWithTags) GetWebEntities() []WebEntity {
+	return s.WebEntities
+}
+
+// GetSafeSearch returns the value of SafeSearch.
+func (s *WallpaperWithTags) GetSafeSearch() OptSafeSearch {
+	return s.SafeSearch
+}
+
 // SetID sets the value of ID.
 func (s *WallpaperWithTags) SetID(val ID) {
 	s.ID = val
@@ -557,13 +2223,105 @@ func (s *WallpaperWithTags) SetColors(val []string) {
 	s.Colors = val
 }
 
+// SetLang sets the value of Lang.
+func (s *WallpaperWithTags) SetLang(val OptString) {
+	s.Lang = val
+}
+
+// SetMachineTranslated sets the value of MachineTranslated.
+func (s *WallpaperWithTags) SetMachineTranslated(val OptBool) {
+	s.MachineTranslated = val
+}
+
+// SetLocation sets the value of Location.
+func (s *WallpaperWithTags) SetLocation(val OptLocation) {
+	s.Location = val
+}
+
+// SetPhotographer sets the value of Photographer.
+func (s *WallpaperWithTags) SetPhotographer(val OptString) {
+	s.Photographer = val
+}
+
+// SetPhotographerSlug sets the value of PhotographerSlug.
+func (s *WallpaperWithTags) SetPhotographerSlug(val OptString) {
+	s.PhotographerSlug = val
+}
+
+// SetAgency sets the value of Agency.
+func (s *WallpaperWithTags) SetAgency(val OptString) {
+	s.Agency = val
+}
+
+// SetHeadline sets the value of Headline.
+func (s *WallpaperWithTags) SetHeadline(val OptString) {
+	s.Headline = val
+}
+
+// SetSearchUrl sets the value of SearchUrl.
+func (s *WallpaperWithTags) SetSearchUrl(val OptString) {
+	s.SearchUrl = val
+}
+
+// SetImages sets the value of Images.
+func (s *WallpaperWithTags) SetImages(val []WallpaperImage) {
+	s.Images = val
+}
+
+// SetBlurHash sets the value of BlurHash.
+func (s *WallpaperWithTags) SetBlurHash(val OptString) {
+	s.BlurHash = val
+}
+
+// SetLqip sets the value of Lqip.
+func (s *WallpaperWithTags) SetLqip(val OptString) {
+	s.Lqip = val
+}
+
 // SetTags sets the value of Tags.
 func (s *WallpaperWithTags) SetTags(val WallpaperWithTagsTags) {
 	s.Tags = val
 }
 
+// SetAppearances sets the value of Appearances.
+func (s *WallpaperWithTags) SetAppearances(val []Appearance) {
+	s.Appearances = val
+}
+
+// SetLocalized sets the value of Localized.
+func (s *WallpaperWithTags) SetLocalized(val OptWallpaperWithTagsLocalized) {
+	s.Localized = val
+}
+
+// SetLandmarks sets the value of Landmarks.
+func (s *WallpaperWithTags) SetLandmarks(val []Landmark) {
+	s.Landmarks = val
+}
+
+// SetWebEntities sets the value of WebEntities.
+func (s *WallpaperWithTags) SetWebEntities(val []WebEntity) {
+	s.WebEntities = val
+}
+
+// SetSafeSearch sets the value of SafeSearch.
+func (s *WallpaperWithTags) SetSafeSearch(val OptSafeSearch) {
+	s.SafeSearch = val
+}
+
 func (*WallpaperWithTags) getWallpaperRes() {}
 
+// Titles and copyrights keyed by language.
+type WallpaperWithTagsLocalized map[string]Localization
+
+func (s *WallpaperWithTagsLocalized) init() WallpaperWithTagsLocalized {
+	m := *s
+	if m == nil {
+		m = map[string]Localization{}
+		*s = m
+	}
+	return m
+}
+
 type WallpaperWithTagsTags map[string]float32
 
 func (s *WallpaperWithTagsTags) init() WallpaperWithTagsTags {
@@ -574,3 +2332,29 @@ func (s *WallpaperWithTagsTags) init() WallpaperWithTagsTags {
 	}
 	return m
 }
+
+// Ref: #/components/schemas/WebEntity
+type WebEntity struct {
+	Description string  `json:"description"`
+	Score       float32 `json:"score"`
+}
+
+// GetDescription returns the value of Description.
+func (s *WebEntity) GetDescription() string {
+	return s.Description
+}
+
+// GetScore returns the value of Score.
+func (s *WebEntity) GetScore() float32 {
+	return s.Score
+}
+
+// SetDescription sets the value of Description.
+func (s *WebEntity) SetDescription(val string) {
+	s.Description = val
+}
+
+// SetScore sets the value of Score.
+func (s *WebEntity) SetScore(val float32) {
+	s.Score = val
+}
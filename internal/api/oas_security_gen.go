@@ -0,0 +1,72 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+// SecurityHandler is handler for security parameters.
+type SecurityHandler interface {
+	// HandleAdminAuth handles adminAuth security.
+	// The ADMIN_TOKEN the server is configured with.
+	HandleAdminAuth(ctx context.Context, operationName OperationName, t AdminAuth) (context.Context, error)
+}
+
+func findAuthorization(h http.Header, prefix string) (string, bool) {
+	v, ok := h["Authorization"]
+	if !ok {
+		return "", false
+	}
+	for _, vv := range v {
+		scheme, value, ok := strings.Cut(vv, " ")
+		if !ok || !strings.EqualFold(scheme, prefix) {
+			continue
+		}
+		return value, true
+	}
+	return "", false
+}
+
+var operationRolesAdminAuth = map[string][]string{
+	GetJobOperation:  []string{},
+	GetJobsOperation: []string{},
+}
+
+func (s *Server) securityAdminAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t AdminAuth
+	token, ok := findAuthorization(req.Header, "Bearer")
+	if !ok {
+		return ctx, false, nil
+	}
+	t.Token = token
+	t.Roles = operationRolesAdminAuth[operationName]
+	rctx, err := s.sec.HandleAdminAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
+// SecuritySource is provider of security values (tokens, passwords, etc.).
+type SecuritySource interface {
+	// AdminAuth provides adminAuth security value.
+	// The ADMIN_TOKEN the server is configured with.
+	AdminAuth(ctx context.Context, operationName OperationName) (AdminAuth, error)
+}
+
+func (s *Client) securityAdminAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.AdminAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"AdminAuth\"")
+	}
+	req.Header.Set("Authorization", "Bearer "+t.Token)
+	return nil
+}
@@ -8,6 +8,24 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// GetJob implements getJob operation.
+	//
+	// Returns an updater run.
+	//
+	// GET /admin/jobs/{id}
+	GetJob(ctx context.Context, params GetJobParams) (GetJobRes, error)
+	// GetJobs implements getJobs operation.
+	//
+	// Returns updater runs, latest first.
+	//
+	// GET /admin/jobs
+	GetJobs(ctx context.Context, params GetJobsParams) ([]Job, error)
+	// GetPhotographers implements getPhotographers operation.
+	//
+	// Returns photographers, most credited first.
+	//
+	// GET /photographers
+	GetPhotographers(ctx context.Context, params GetPhotographersParams) ([]Photographer, error)
 	// GetRoot implements getRoot operation.
 	//
 	// GET /
@@ -18,6 +36,12 @@ type Handler interface {
 	//
 	// GET /wallpapers/{id}
 	GetWallpaper(ctx context.Context, params GetWallpaperParams) (GetWallpaperRes, error)
+	// GetWallpaperImage implements getWallpaperImage operation.
+	//
+	// Without w or h the source size is kept, and with one of them the other follows the aspect ratio.
+	//
+	// GET /wallpapers/{id}/image
+	GetWallpaperImage(ctx context.Context, params GetWallpaperImageParams) (GetWallpaperImageRes, error)
 	// GetWallpaperTags implements getWallpaperTags operation.
 	//
 	// Returns a list of tags.
@@ -30,29 +54,55 @@ type Handler interface {
 	//
 	// GET /wallpapers
 	GetWallpapers(ctx context.Context, params GetWallpapersParams) (GetWallpapersRes, error)
+	// GetWallpapersByCountry implements getWallpapersByCountry operation.
+	//
+	// Returns a list of wallpapers taken in the given country.
+	//
+	// GET /countries/{code}/wallpapers
+	GetWallpapersByCountry(ctx context.Context, params GetWallpapersByCountryParams) (GetWallpapersByCountryRes, error)
+	// GetWallpapersByLandmark implements getWallpapersByLandmark operation.
+	//
+	// Returns a list of wallpapers showing the given landmark.
+	//
+	// GET /landmarks/{slug}/wallpapers
+	GetWallpapersByLandmark(ctx context.Context, params GetWallpapersByLandmarkParams) (GetWallpapersByLandmarkRes, error)
+	// GetWallpapersByPhotographer implements getWallpapersByPhotographer operation.
+	//
+	// Returns a list of wallpapers by the given photographer.
+	//
+	// GET /photographers/{slug}/wallpapers
+	GetWallpapersByPhotographer(ctx context.Context, params GetWallpapersByPhotographerParams) (GetWallpapersByPhotographerRes, error)
 	// GetWallpapersByTag implements getWallpapersByTag operation.
 	//
 	// Returns a list of wallpapers with the given tag.
 	//
 	// GET /wallpapers/tags/{tag}
 	GetWallpapersByTag(ctx context.Context, params GetWallpapersByTagParams) (GetWallpapersByTagRes, error)
+	// GetWallpapersNear implements getWallpapersNear operation.
+	//
+	// Returns wallpapers taken near a location, nearest first.
+	//
+	// GET /wallpapers/near
+	GetWallpapersNear(ctx context.Context, params GetWallpapersNearParams) (GetWallpapersNearRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
 // calls Handler to handle requests.
 type Server struct {
-	h Handler
+	h   Handler
+	sec SecurityHandler
 	baseServer
 }
 
 // NewServer creates new Server.
-func NewServer(h Handler, opts ...ServerOption) (*Server, error) {
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
 	s, err := newServerConfig(opts...).baseServer()
 	if err != nil {
 		return nil, err
 	}
 	return &Server{
 		h:          h,
+		sec:        sec,
 		baseServer: s,
 	}, nil
 }
@@ -13,6 +13,33 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// GetJob implements getJob operation.
+//
+// Returns an updater run.
+//
+// GET /admin/jobs/{id}
+func (UnimplementedHandler) GetJob(ctx context.Context, params GetJobParams) (r GetJobRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetJobs implements getJobs operation.
+//
+// Returns updater runs, latest first.
+//
+// GET /admin/jobs
+func (UnimplementedHandler) GetJobs(ctx context.Context, params GetJobsParams) (r []Job, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetPhotographers implements getPhotographers operation.
+//
+// Returns photographers, most credited first.
+//
+// GET /photographers
+func (UnimplementedHandler) GetPhotographers(ctx context.Context, params GetPhotographersParams) (r []Photographer, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetRoot implements getRoot operation.
 //
 // GET /
@@ -29,6 +56,15 @@ func (UnimplementedHandler) GetWallpaper(ctx context.Context, params GetWallpape
 	return r, ht.ErrNotImplemented
 }
 
+// GetWallpaperImage implements getWallpaperImage operation.
+//
+// Without w or h the source size is kept, and with one of them the other follows the aspect ratio.
+//
+// GET /wallpapers/{id}/image
+func (UnimplementedHandler) GetWallpaperImage(ctx context.Context, params GetWallpaperImageParams) (r GetWallpaperImageRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetWallpaperTags implements getWallpaperTags operation.
 //
 // Returns a list of tags.
@@ -47,6 +83,33 @@ func (UnimplementedHandler) GetWallpapers(ctx context.Context, params GetWallpap
 	return r, ht.ErrNotImplemented
 }
 
+// GetWallpapersByCountry implements getWallpapersByCountry operation.
+//
+// Returns a list of wallpapers taken in the given country.
+//
+// GET /countries/{code}/wallpapers
+func (UnimplementedHandler) GetWallpapersByCountry(ctx context.Context, params GetWallpapersByCountryParams) (r GetWallpapersByCountryRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetWallpapersByLandmark implements getWallpapersByLandmark operation.
+//
+// Returns a list of wallpapers showing the given landmark.
+//
+// GET /landmarks/{slug}/wallpapers
+func (UnimplementedHandler) GetWallpapersByLandmark(ctx context.Context, params GetWallpapersByLandmarkParams) (r GetWallpapersByLandmarkRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetWallpapersByPhotographer implements getWallpapersByPhotographer operation.
+//
+// Returns a list of wallpapers by the given photographer.
+//
+// GET /photographers/{slug}/wallpapers
+func (UnimplementedHandler) GetWallpapersByPhotographer(ctx context.Context, params GetWallpapersByPhotographerParams) (r GetWallpapersByPhotographerRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetWallpapersByTag implements getWallpapersByTag operation.
 //
 // Returns a list of wallpapers with the given tag.
@@ -55,3 +118,12 @@ func (UnimplementedHandler) GetWallpapers(ctx context.Context, params GetWallpap
 func (UnimplementedHandler) GetWallpapersByTag(ctx context.Context, params GetWallpapersByTagParams) (r GetWallpapersByTagRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// GetWallpapersNear implements getWallpapersNear operation.
+//
+// Returns wallpapers taken near a location, nearest first.
+//
+// GET /wallpapers/near
+func (UnimplementedHandler) GetWallpapersNear(ctx context.Context, params GetWallpapersNearParams) (r GetWallpapersNearRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
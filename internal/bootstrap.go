@@ -2,81 +2,146 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"syscall"
+	"time"
 
+	"cloud.google.com/go/firestore"
 	firebase "firebase.google.com/go"
 
 	"api/internal/api"
+	"api/internal/collections"
+	"api/internal/fetch"
 	"api/internal/handler"
 	"api/internal/server"
 	"api/internal/store"
-	"api/internal/updater"
-	"api/internal/updater/pubsub"
+	"api/internal/thumb"
 )
 
-const collection = "BingWallpapers"
+const (
+	defaultImageCacheSize = 1 << 30
 
-func Bootstrap() error {
-	ctx := context.Background()
+	// shutdownTimeout is how long in-flight requests are given to finish on shutdown
+	shutdownTimeout = 10 * time.Second
+)
 
-	conf := &firebase.Config{ProjectID: os.Getenv("PROJECT_ID")}
-	firebaseClient, err := firebase.NewApp(ctx, conf)
-	if err != nil {
+// Bootstrap serves the API and runs listen, e.g. updater.Listen, in the same process,
+// until it receives an interrupt or termination signal.
+func Bootstrap(listen func(ctx context.Context, fs *firestore.Client) error) error {
+	return Run(func(ctx context.Context, fs *firestore.Client) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		errs := make(chan error, 2)
+		go func() { errs <- listen(ctx, fs) }()
+		go func() { errs <- Serve(ctx, fs) }()
+
+		// stop the other one as soon as either stops
+		err := <-errs
+		cancel()
+		if err2 := <-errs; err == nil {
+			err = err2
+		}
 		return err
-	}
+	})
+}
 
-	firestore, err := firebaseClient.Firestore(ctx)
+// Run runs fn with a Firestore client until it returns or an interrupt or termination signal is received,
+// closing the client afterwards.
+func Run(fn func(ctx context.Context, fs *firestore.Client) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fs, err := NewFirestore(ctx)
 	if err != nil {
 		return err
 	}
+	defer fs.Close()
 
-	wallpaperClient := store.New(collection, firestore)
+	return fn(ctx, fs)
+}
 
-	hh := handler.New(&wallpaperClient)
-	h, err := api.NewServer(hh)
+// NewFirestore connects to the Firestore database of PROJECT_ID, shared by the API and the updater.
+func NewFirestore(ctx context.Context) (*firestore.Client, error) {
+	conf := &firebase.Config{ProjectID: os.Getenv("PROJECT_ID")}
+	firebaseClient, err := firebase.NewApp(ctx, conf)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	port := os.Getenv("PORT")
+	return firebaseClient.Firestore(ctx)
+}
 
-	srv := server.New(port, h)
+// Serve serves the API on PORT until ctx is done, then shuts the server down gracefully.
+func Serve(ctx context.Context, fs *firestore.Client) error {
+	wallpaperClient := store.New(collections.Wallpapers, fs)
 
-	u, err := updater.New()
+	renderer, err := newRenderer()
 	if err != nil {
 		return err
 	}
 
-	errs := make(chan error, 2)
-	go func() {
-		if err := pubsub.Start(ctx, os.Getenv("PROJECT_ID"), updater.TopicID, updater.SubID, u.Update); err != nil {
-			errs <- err
-		}
-	}()
+	hh := handler.New(&wallpaperClient, renderer)
+	h, err := api.NewServer(hh, handler.NewSecurity(os.Getenv("ADMIN_TOKEN")))
+	if err != nil {
+		return err
+	}
+
+	port := os.Getenv("PORT")
+	srv := server.New(port, h)
+
+	errs := make(chan error, 1)
 	go func() {
 		log.Printf("server started on http://localhost:%s", port)
-		if err := srv.ListenAndServe(); err != nil {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errs <- err
 		}
 	}()
 
-	exit := make(chan os.Signal, 1)
-	signal.Notify(exit, os.Interrupt, syscall.SIGTERM)
-
 	select {
 	case err := <-errs:
-		// Attempt graceful shutdown on error
-		_ = srv.Shutdown(ctx)
 		return err
-	case <-exit:
-		log.Println("shutdown signal received")
-		if err := srv.Shutdown(ctx); err != nil {
-			return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	log.Println("server stopped")
+	return nil
+}
+
+// newRenderer configures resized images to be cached in IMAGE_CACHE_DIR, up to IMAGE_CACHE_SIZE bytes.
+func newRenderer() (*thumb.Renderer, error) {
+	dir := os.Getenv("IMAGE_CACHE_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "wallpaper-images")
+	}
+
+	size := int64(defaultImageCacheSize)
+	if v := os.Getenv("IMAGE_CACHE_SIZE"); v != "" {
+		var err error
+		size, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image cache size %q: %w", v, err)
 		}
-		log.Println("server stopped")
-		return nil
 	}
+
+	cache, err := thumb.NewCache(dir, size)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := &fetch.Fetcher{HC: &http.Client{Timeout: 15 * time.Second}, UserAgent: os.Getenv("USER_AGENT")}
+	return thumb.NewRenderer(fetcher, cache), nil
 }
@@ -0,0 +1,11 @@
+// Package collections names the Firestore collections shared by the API and the updater.
+package collections
+
+const (
+	// Wallpapers holds a wallpaper per ID, stored by the updater and served by the API.
+	Wallpapers = "BingWallpapers"
+	// Photographers holds a photographer per slug, counted by the updater and listed by the API.
+	Photographers = "Photographers"
+	// Jobs holds a job per updater run, listed by the API's admin endpoints.
+	Jobs = "UpdaterJobs"
+)
@@ -0,0 +1,215 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultUserAgent   = "bing-wallpapers-updater/1.0"
+	DefaultMaxRetries  = 3
+	DefaultMinBackoff  = 500 * time.Millisecond
+	DefaultMaxBackoff  = 30 * time.Second
+	DefaultMaxBodySize = 50 << 20
+)
+
+// ErrTooLarge is returned when a response body exceeds MaxBodySize.
+var ErrTooLarge = errors.New("response body too large")
+
+// StatusError is returned by Get for responses other than 200 OK.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Fetcher sends requests with a user agent, retrying transient failures with exponential backoff and jitter.
+// Zero values use the defaults; a negative MaxRetries disables retries.
+type Fetcher struct {
+	HC          *http.Client
+	UserAgent   string
+	MaxRetries  int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	MaxBodySize int64
+
+	sleep func(ctx context.Context, d time.Duration) error // overridden in tests
+}
+
+// Get returns the body of url, failing with a *StatusError unless the response is 200 OK.
+func (f *Fetcher) Get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	maxBodySize := f.maxBodySize()
+	if resp.ContentLength > maxBodySize {
+		return nil, fmt.Errorf("%s: %w: %d bytes", url, ErrTooLarge, resp.ContentLength)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if int64(len(body)) > maxBodySize {
+		return nil, fmt.Errorf("%s: %w: over %d bytes", url, ErrTooLarge, maxBodySize)
+	}
+
+	return body, nil
+}
+
+// Do sends req, retrying network errors, 429 and 5xx responses other than 501.
+// The last response is returned whatever its status; the caller must close its body.
+// Requests with a body are only retried if it can be replayed with GetBody.
+func (f *Fetcher) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	hc := f.HC
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(ctx)
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+		if r.Header.Get("User-Agent") == "" {
+			r.Header.Set("User-Agent", f.userAgent())
+		}
+
+		resp, err := hc.Do(r)
+
+		retryable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if attempt >= f.maxRetries() || !retryable || ctx.Err() != nil {
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s: %w", req.URL, err)
+			}
+			return resp, nil
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			delay = f.backoff(attempt)
+		case transient(resp.StatusCode):
+			delay = f.backoff(attempt)
+			if after, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if after > f.maxBackoff() {
+					// not worth waiting for
+					return resp, nil
+				}
+				delay = max(delay, after)
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			_ = resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if err := f.wait(ctx, delay); err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", req.URL, err)
+		}
+	}
+}
+
+// transient reports whether a request may succeed if retried.
+func transient(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500 && status != http.StatusNotImplemented
+}
+
+// backoff returns a random delay up to MinBackoff doubled per attempt, capped at MaxBackoff.
+func (f *Fetcher) backoff(attempt int) time.Duration {
+	ceiling := f.maxBackoff()
+	if d := f.minBackoff() << attempt; d > 0 && d < ceiling {
+		ceiling = d
+	}
+	return rand.N(ceiling) + 1
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP date.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func (f *Fetcher) wait(ctx context.Context, d time.Duration) error {
+	if f.sleep != nil {
+		return f.sleep(ctx, d)
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (f *Fetcher) userAgent() string {
+	if f.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return f.UserAgent
+}
+
+func (f *Fetcher) maxRetries() int {
+	if f.MaxRetries == 0 {
+		return DefaultMaxRetries
+	}
+	return max(f.MaxRetries, 0)
+}
+
+func (f *Fetcher) minBackoff() time.Duration {
+	if f.MinBackoff <= 0 {
+		return DefaultMinBackoff
+	}
+	return f.MinBackoff
+}
+
+func (f *Fetcher) maxBackoff() time.Duration {
+	if f.MaxBackoff <= 0 {
+		return DefaultMaxBackoff
+	}
+	return f.MaxBackoff
+}
+
+func (f *Fetcher) maxBodySize() int64 {
+	if f.MaxBodySize <= 0 {
+		return DefaultMaxBodySize
+	}
+	return f.MaxBodySize
+}
@@ -0,0 +1,184 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFetcher returns a Fetcher that records its delays instead of sleeping.
+func newFetcher(delays *[]time.Duration) *Fetcher {
+	return &Fetcher{
+		HC:         &http.Client{Timeout: time.Second},
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: 10 * time.Second,
+		sleep: func(ctx context.Context, d time.Duration) error {
+			*delays = append(*delays, d)
+			return ctx.Err()
+		},
+	}
+}
+
+func TestFetcher_Get_RetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	f := newFetcher(&delays)
+	f.UserAgent = "test-agent"
+
+	body, err := f.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.EqualValues(t, 3, calls.Load())
+
+	require.Len(t, delays, 2)
+	assert.LessOrEqual(t, delays[0], 100*time.Millisecond)
+	assert.LessOrEqual(t, delays[1], 200*time.Millisecond)
+}
+
+func TestFetcher_Get_GivesUp(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	f := newFetcher(&delays)
+	f.MaxRetries = 2
+
+	_, err := f.Get(context.Background(), server.URL)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestFetcher_Get_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	_, err := newFetcher(&delays).Get(context.Background(), server.URL)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.EqualValues(t, 1, calls.Load())
+	assert.Empty(t, delays)
+}
+
+func TestFetcher_Get_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	_, err := newFetcher(&delays).Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{3 * time.Second}, delays)
+}
+
+func TestFetcher_Get_RetryAfterTooLong(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	_, err := newFetcher(&delays).Get(context.Background(), server.URL)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Empty(t, delays)
+}
+
+func TestFetcher_Get_MaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// flushing first sends the body chunked, without a Content-Length
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(strings.Repeat("x", 11)))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	f := newFetcher(&delays)
+	f.MaxBodySize = 10
+
+	_, err := f.Get(context.Background(), server.URL)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	f.MaxBodySize = 11
+	body, err := f.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Len(t, body, 11)
+}
+
+func TestFetcher_Get_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &Fetcher{
+		HC: &http.Client{Timeout: time.Second},
+		sleep: func(ctx context.Context, d time.Duration) error {
+			cancel()
+			return ctx.Err()
+		},
+	}
+
+	_, err := f.Get(ctx, server.URL)
+	assert.True(t, errors.Is(err, context.Canceled), err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2023, 2, 20, 8, 0, 0, 0, time.UTC)
+
+	d, ok := retryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, d)
+
+	d, ok = retryAfter("Mon, 20 Feb 2023 08:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	d, ok = retryAfter("Mon, 20 Feb 2023 07:00:00 GMT", now)
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	_, ok = retryAfter("soon", now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	earthRadius = 6371.0 // km
+	kmPerDegree = earthRadius * math.Pi / 180
+
+	base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+	// Precision is the geohash length stored for each wallpaper, about 5m x 5m.
+	Precision = 9
+)
+
+// Distance returns the great-circle distance in km between two points.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	φ1, φ2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dφ := φ2 - φ1
+	dλ := (lng2 - lng1) * math.Pi / 180
+
+	a := math.Sin(dφ/2)*math.Sin(dφ/2) + math.Cos(φ1)*math.Cos(φ2)*math.Sin(dλ/2)*math.Sin(dλ/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(min(1, a)))
+}
+
+// Geohash encodes a point as a geohash of the given length.
+func Geohash(lat, lng float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var b strings.Builder
+	even := true // geohashes interleave bits starting with longitude
+	bit, ch := 0, 0
+	for b.Len() < precision {
+		r, v := &latRange, lat
+		if even {
+			r, v = &lngRange, lng
+		}
+
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			b.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return b.String()
+}
+
+// Cells returns geohash prefixes whose cells together cover the circle of radius km around a point.
+// An empty prefix, covering the whole world, is returned if the radius is too large for any cell.
+func Cells(lat, lng, radius float64) []string {
+	precision, cellLat, cellLng := 0, 180.0, 360.0
+	for p := 1; p <= Precision; p++ {
+		h, w := cellSize(p)
+		if h*kmPerDegree < radius || w*kmPerDegree*math.Cos(lat*math.Pi/180) < radius {
+			break
+		}
+		precision, cellLat, cellLng = p, h, w
+	}
+	if precision == 0 {
+		return []string{""}
+	}
+
+	// the circle fits within the cell containing the point and its eight neighbours
+	var cells []string
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			y := math.Max(-90, math.Min(90, lat+float64(dy)*cellLat))
+			x := math.Mod(lng+float64(dx)*cellLng+540, 360) - 180
+			if c := Geohash(y, x, precision); !slices.Contains(cells, c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// cellSize returns the height and width in degrees of a geohash cell with the given length.
+func cellSize(precision int) (lat, lng float64) {
+	bits := 5 * precision
+	return 180 / math.Exp2(float64(bits/2)), 360 / math.Exp2(float64(bits-bits/2))
+}
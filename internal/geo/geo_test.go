@@ -0,0 +1,48 @@
+package geo_test
+
+import (
+	"strings"
+	"testing"
+
+	"api/internal/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeohash(t *testing.T) {
+	assert.Equal(t, "u4pruydqqvj", geo.Geohash(57.64911, 10.40744, 11))
+	assert.Equal(t, "dqcjpp8eg", geo.Geohash(38.8895, -77.0353, 9))
+}
+
+func TestDistance(t *testing.T) {
+	// Washington Monument to the Eiffel Tower
+	assert.InDelta(t, 6162, geo.Distance(38.8895, -77.0353, 48.8584, 2.2945), 10)
+	assert.Zero(t, geo.Distance(1, 2, 1, 2))
+}
+
+func TestCells(t *testing.T) {
+	lat, lng := 38.8895, -77.0353
+	hash := geo.Geohash(lat, lng, geo.Precision)
+
+	cells := geo.Cells(lat, lng, 10)
+	assert.Len(t, cells, 9)
+	for _, c := range cells {
+		assert.Len(t, c, 4)
+	}
+
+	// the Capitol, 2.4km away, falls in one of the cells
+	capitol := geo.Geohash(38.8899, -77.0091, geo.Precision)
+	assert.True(t, containsPrefix(cells, hash))
+	assert.True(t, containsPrefix(cells, capitol))
+
+	assert.Equal(t, []string{""}, geo.Cells(lat, lng, 20000))
+}
+
+func containsPrefix(cells []string, hash string) bool {
+	for _, c := range cells {
+		if strings.HasPrefix(hash, c) {
+			return true
+		}
+	}
+	return false
+}
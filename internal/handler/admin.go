@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"api/internal/api"
+)
+
+// DefaultJobs is the default number of jobs returned.
+const DefaultJobs = 20
+
+// ErrUnauthorized is returned for admin requests without the admin token.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Security authorizes admin requests by a bearer token.
+type Security struct {
+	adminToken string
+}
+
+// NewSecurity accepts adminToken on admin endpoints, which are disabled if it is empty.
+func NewSecurity(adminToken string) Security {
+	return Security{adminToken: adminToken}
+}
+
+func (s Security) HandleAdminAuth(ctx context.Context, _ api.OperationName, t api.AdminAuth) (context.Context, error) {
+	if s.adminToken == "" || subtle.ConstantTimeCompare([]byte(t.Token), []byte(s.adminToken)) != 1 {
+		return ctx, ErrUnauthorized
+	}
+	return ctx, nil
+}
+
+func (h Handler) GetJobs(ctx context.Context, p api.GetJobsParams) ([]api.Job, error) {
+	limit := DefaultJobs
+	if p.Limit.Set {
+		limit = p.Limit.Value
+	}
+
+	jobs, err := h.store.ListJobs(ctx, limit, p.StartAfterID.Or(""))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]api.Job, len(jobs))
+	for i, j := range jobs {
+		res[i] = j.ToAPI()
+	}
+	return res, nil
+}
+
+func (h Handler) GetJob(ctx context.Context, p api.GetJobParams) (api.GetJobRes, error) {
+	job, err := h.store.GetJob(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return &api.GetJobNotFound{}, nil
+	}
+
+	res := job.ToAPI()
+	return &res, nil
+}
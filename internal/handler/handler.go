@@ -1,12 +1,20 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"api/internal/api"
 	"api/internal/store"
+	"api/internal/thumb"
+
+	"golang.org/x/text/language"
 )
 
 const (
@@ -15,14 +23,24 @@ const (
 
 	// MaxTagScore is the maximum Vision API confidence score (used as initial cursor).
 	MaxTagScore = 1.0
+
+	// DefaultRadius is the default distance in km when listing wallpapers near a location.
+	DefaultRadius = 50.0
+
+	// DefaultPhotographers is the default number of photographers returned.
+	DefaultPhotographers = 100
+
+	// ImageCacheControl lets clients and CDNs cache resized images for 30 days.
+	ImageCacheControl = "public, max-age=2592000"
 )
 
 type Handler struct {
-	store store.Storer
+	store    store.Storer
+	renderer *thumb.Renderer
 }
 
-func New(store store.Storer) Handler {
-	return Handler{store: store}
+func New(store store.Storer, renderer *thumb.Renderer) Handler {
+	return Handler{store: store, renderer: renderer}
 }
 
 func (h Handler) GetRoot(_ context.Context) error {
@@ -30,34 +48,105 @@ func (h Handler) GetRoot(_ context.Context) error {
 }
 
 func (h Handler) GetWallpaper(ctx context.Context, p api.GetWallpaperParams) (api.GetWallpaperRes, error) {
-	id := string(p.ID)
+	wp, err := h.get(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if wp == nil {
+		return &api.GetWallpaperNotFound{}, nil
+	}
 
-	var wp *store.WallpaperWithTags
-	if i, err := strconv.Atoi(id); err == nil {
-		wp, err = h.store.GetByOldID(ctx, i)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		wp, err = h.store.Get(ctx, string(p.ID))
-		if err != nil {
-			return nil, err
-		}
+	w := wp.Localize(preferredLanguages(p.Lang, p.AcceptLanguage))
+
+	res := &api.WallpaperWithTags{
+		ID:          api.ID(w.ID),
+		Title:       w.Title,
+		Copyright:   w.Copyright,
+		Date:        api.Date(w.Date),
+		Market:      w.Market,
+		UrlBase:     w.URLBase,
+		Tags:        wp.Tags,
+		Colors:      w.Colors,
+		Appearances: store.AppearancesToAPI(wp.Appearances),
+		Localized:   api.NewOptWallpaperWithTagsLocalized(store.LocalizedToAPI(wp.Localized)),
+		Landmarks:   store.LandmarksToAPI(wp.Landmarks),
+		WebEntities: store.WebEntitiesToAPI(wp.WebEntities),
+		SafeSearch:  store.SafeSearchToAPI(wp.SafeSearch),
+		Location:    w.LocationToAPI(),
+		Images:      w.ImagesToAPI(),
+	}
+	if w.Photographer != "" {
+		res.Photographer = api.NewOptString(w.Photographer)
+		res.PhotographerSlug = api.NewOptString(w.PhotographerSlug)
+	}
+	if w.Agency != "" {
+		res.Agency = api.NewOptString(w.Agency)
+	}
+	if w.Headline != "" {
+		res.Headline = api.NewOptString(w.Headline)
 	}
+	if w.BlurHash != "" {
+		res.BlurHash = api.NewOptString(w.BlurHash)
+	}
+	if w.LQIP != "" {
+		res.Lqip = api.NewOptString(w.LQIP)
+	}
+	if w.CopyrightLink != "" {
+		res.SearchUrl = api.NewOptString(w.CopyrightLink)
+	}
+	if w.Lang != "" {
+		res.Lang = api.NewOptString(w.Lang)
+		res.MachineTranslated = api.NewOptBool(w.MachineTranslated)
+	}
+
+	return res, nil
+}
+
+// get returns a wallpaper by ID, or by the numeric ID it had on the old site.
+func (h Handler) get(ctx context.Context, id api.ID) (*store.WallpaperWithTags, error) {
+	if i, err := strconv.Atoi(string(id)); err == nil {
+		return h.store.GetByOldID(ctx, i)
+	}
+	return h.store.Get(ctx, string(id))
+}
 
+func (h Handler) GetWallpaperImage(ctx context.Context, p api.GetWallpaperImageParams) (api.GetWallpaperImageRes, error) {
+	wp, err := h.get(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
 	if wp == nil {
-		return &api.GetWallpaperNotFound{}, nil
+		return &api.GetWallpaperImageNotFound{}, nil
+	}
+
+	opts := thumb.Options{
+		Width:  p.W.Or(0),
+		Height: p.H.Or(0),
+		Fit:    thumb.Fit(p.Fit.Or(api.GetWallpaperImageFitContain)),
+		Format: thumb.Format(p.Format.Or(api.GetWallpaperImageFormatJpeg)),
 	}
 
-	return &api.WallpaperWithTags{
-		ID:        api.ID(wp.ID),
-		Title:     wp.Title,
-		Copyright: wp.Copyright,
-		Date:      api.Date(wp.Date),
-		Market:    wp.Market,
-		UrlBase:   wp.URLBase,
-		Tags:      wp.Tags,
-		Colors:    wp.Colors,
+	source, checksum := wp.Source(opts.Width, opts.Height)
+	b, etag, err := h.renderer.Render(ctx, source, checksum, opts)
+	if errors.Is(err, thumb.ErrSource) {
+		return &api.GetWallpaperImageBadGateway{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cacheControl := api.NewOptString(ImageCacheControl)
+	if opts.Format == thumb.PNG {
+		return &api.GetWallpaperImageOKImagePNGHeaders{
+			CacheControl: cacheControl,
+			ETag:         api.NewOptString(etag),
+			Response:     api.GetWallpaperImageOKImagePNG{Data: bytes.NewReader(b)},
+		}, nil
+	}
+	return &api.GetWallpaperImageOKImageJpegHeaders{
+		CacheControl: cacheControl,
+		ETag:         api.NewOptString(etag),
+		Response:     api.GetWallpaperImageOKImageJpeg{Data: bytes.NewReader(b)},
 	}, nil
 }
 
@@ -88,6 +177,22 @@ func (h Handler) GetWallpapers(ctx context.Context, p api.GetWallpapersParams) (
 		q.Limit = p.Limit.Value
 	}
 
+	var filter string
+	if p.Market.Set {
+		q.Market = p.Market.Value
+		filter = "&market=" + url.QueryEscape(q.Market)
+	}
+
+	if p.Agency.Set {
+		q.Agency = p.Agency.Value
+		filter += "&agency=" + url.QueryEscape(q.Agency)
+	}
+
+	if p.Orientation.Set {
+		q.Orientation = string(p.Orientation.Value)
+		filter += "&orientation=" + q.Orientation
+	}
+
 	wallpapers, err := h.store.List(ctx, q)
 	if err != nil {
 		return nil, err
@@ -97,17 +202,22 @@ func (h Handler) GetWallpapers(ctx context.Context, p api.GetWallpapersParams) (
 		return &api.GetWallpapersNotFound{}, nil
 	}
 
+	if p.Safe.Set {
+		filter += "&safe=1"
+	}
+
 	res := api.WallpaperList{
-		Data: store.ToAPI(wallpapers),
+		Data: store.ToAPI(safeOnly(wallpapers, p.Safe.Set), preferredLanguages(p.Lang, p.AcceptLanguage)),
 	}
 
+	// links are based on the unfiltered page so filtered wallpapers are not fetched again
 	last := wallpapers[len(wallpapers)-1]
-	res.Links = api.Links{Next: api.NewOptString(fmt.Sprintf("/wallpapers?startAfterDate=%d&startAfterID=%s", last.Date, last.ID))}
+	res.Links = api.Links{Next: api.NewOptString(fmt.Sprintf("/wallpapers?startAfterDate=%d&startAfterID=%s%s", last.Date, last.ID, filter))}
 
 	showPrev := p.StartAfterDate.Set && p.StartAfterID.Set
 	if showPrev {
 		first := wallpapers[0]
-		res.Links.Prev = api.NewOptString(fmt.Sprintf("/wallpapers?startAfterDate=%d&startAfterID=%s&prev=1", first.Date, first.ID))
+		res.Links.Prev = api.NewOptString(fmt.Sprintf("/wallpapers?startAfterDate=%d&startAfterID=%s&prev=1%s", first.Date, first.ID, filter))
 	}
 
 	return &res, nil
@@ -129,7 +239,7 @@ func (h Handler) GetWallpapersByTag(ctx context.Context, p api.GetWallpapersByTa
 	}
 
 	res := api.WallpaperList{
-		Data: store.ToAPI(wallpapers),
+		Data: store.ToAPI(wallpapers, preferredLanguages(p.Lang, p.AcceptLanguage)),
 	}
 
 	if len(wallpapers) == store.TagPageSize && next > 0 {
@@ -138,3 +248,148 @@ func (h Handler) GetWallpapersByTag(ctx context.Context, p api.GetWallpapersByTa
 
 	return &res, nil
 }
+
+func (h Handler) GetWallpapersByLandmark(ctx context.Context, p api.GetWallpapersByLandmarkParams) (api.GetWallpapersByLandmarkRes, error) {
+	q := store.ListQuery{Landmark: p.Slug}
+	path := fmt.Sprintf("/landmarks/%s/wallpapers", url.PathEscape(p.Slug))
+
+	res, err := h.listPage(ctx, q, path, p.StartAfterDate, p.StartAfterID, p.Safe.Set, preferredLanguages(p.Lang, p.AcceptLanguage))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &api.GetWallpapersByLandmarkNotFound{}, nil
+	}
+	return res, nil
+}
+
+func (h Handler) GetWallpapersByCountry(ctx context.Context, p api.GetWallpapersByCountryParams) (api.GetWallpapersByCountryRes, error) {
+	q := store.ListQuery{Country: strings.ToUpper(p.Code)}
+	path := fmt.Sprintf("/countries/%s/wallpapers", url.PathEscape(q.Country))
+
+	res, err := h.listPage(ctx, q, path, p.StartAfterDate, p.StartAfterID, p.Safe.Set, preferredLanguages(p.Lang, p.AcceptLanguage))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &api.GetWallpapersByCountryNotFound{}, nil
+	}
+	return res, nil
+}
+
+func (h Handler) GetWallpapersByPhotographer(ctx context.Context, p api.GetWallpapersByPhotographerParams) (api.GetWallpapersByPhotographerRes, error) {
+	q := store.ListQuery{Photographer: p.Slug}
+	path := fmt.Sprintf("/photographers/%s/wallpapers", url.PathEscape(p.Slug))
+
+	res, err := h.listPage(ctx, q, path, p.StartAfterDate, p.StartAfterID, p.Safe.Set, preferredLanguages(p.Lang, p.AcceptLanguage))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &api.GetWallpapersByPhotographerNotFound{}, nil
+	}
+	return res, nil
+}
+
+func (h Handler) GetPhotographers(ctx context.Context, p api.GetPhotographersParams) ([]api.Photographer, error) {
+	limit := DefaultPhotographers
+	if p.Limit.Set {
+		limit = p.Limit.Value
+	}
+
+	photographers, err := h.store.ListPhotographers(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.PhotographersToAPI(photographers), nil
+}
+
+// listPage returns a page of the wallpapers matching q with a link to the next page at path,
+// or nil if there are none.
+func (h Handler) listPage(ctx context.Context, q store.ListQuery, path string, startAfterDate api.OptDate, startAfterID api.OptID, safe bool, prefs []language.Tag) (*api.WallpaperList, error) {
+	q.Limit = DefaultPageSize
+
+	if startAfterDate.Set {
+		q.StartAfterDate = int(startAfterDate.Value)
+	}
+
+	if startAfterID.Set {
+		q.StartAfterID = string(startAfterID.Value)
+	}
+
+	wallpapers, err := h.store.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wallpapers) == 0 {
+		return nil, nil
+	}
+
+	var filter string
+	if safe {
+		filter = "&safe=1"
+	}
+
+	res := api.WallpaperList{
+		Data: store.ToAPI(safeOnly(wallpapers, safe), prefs),
+	}
+
+	if len(wallpapers) == q.Limit {
+		last := wallpapers[len(wallpapers)-1]
+		res.Links = api.Links{Next: api.NewOptString(fmt.Sprintf("%s?startAfterDate=%d&startAfterID=%s%s", path, last.Date, last.ID, filter))}
+	}
+
+	return &res, nil
+}
+
+func (h Handler) GetWallpapersNear(ctx context.Context, p api.GetWallpapersNearParams) (api.GetWallpapersNearRes, error) {
+	radius := DefaultRadius
+	if p.Radius.Set {
+		radius = p.Radius.Value
+	}
+
+	limit := DefaultPageSize
+	if p.Limit.Set {
+		limit = p.Limit.Value
+	}
+
+	wallpapers, err := h.store.ListNear(ctx, p.Lat, p.Lng, radius, limit, p.Safe.Set)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wallpapers) == 0 {
+		return &api.GetWallpapersNearNotFound{}, nil
+	}
+
+	return &api.WallpaperList{
+		Data: store.ToAPI(wallpapers, preferredLanguages(p.Lang, p.AcceptLanguage)),
+	}, nil
+}
+
+// safeOnly returns the wallpapers that are safe if safe is set, and all of them otherwise.
+func safeOnly(wallpapers []store.Wallpaper, safe bool) []store.Wallpaper {
+	if !safe {
+		return wallpapers
+	}
+	return slices.DeleteFunc(slices.Clone(wallpapers), func(w store.Wallpaper) bool { return !w.Safe() })
+}
+
+// preferredLanguages returns the languages requested by the lang parameter, or else the Accept-Language header.
+func preferredLanguages(lang, acceptLanguage api.OptString) []language.Tag {
+	if lang.Set {
+		if tag, err := language.Parse(lang.Value); err == nil {
+			return []language.Tag{tag}
+		}
+	}
+
+	if acceptLanguage.Set {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage.Value); err == nil {
+			return tags
+		}
+	}
+
+	return nil
+}
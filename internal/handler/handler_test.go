@@ -0,0 +1,352 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api/internal/api"
+	"api/internal/fetch"
+	"api/internal/handler"
+	"api/internal/store"
+	"api/internal/thumb"
+	"api/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const adminToken = "secret"
+
+var (
+	presDay = store.Wallpaper{ID: "PresDayDC", Title: "Washington Monument", Date: 20230220, Market: "en-US"}
+	maui    = store.Wallpaper{ID: "MauiWhale", Title: "Humpback whales", Date: 20230219, Market: "en-US"}
+	racy    = store.Wallpaper{ID: "Racy", Title: "Racy", Date: 20230218, Market: "en-US", SafeSearch: &store.SafeSearch{Racy: "LIKELY"}}
+)
+
+// newServer serves the API over db, authorizing admin requests with adminToken.
+func newServer(t *testing.T, db *mocks.Storer) *httptest.Server {
+	t.Helper()
+	renderer := thumb.NewRenderer(&fetch.Fetcher{HC: &http.Client{Timeout: time.Second}, MaxRetries: -1}, nil)
+	h, err := api.NewServer(handler.New(db, renderer), handler.NewSecurity(adminToken))
+	require.NoError(t, err)
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// list is the part of a wallpaper list the tests check.
+type list struct {
+	Data []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"data"`
+	Links struct {
+		Next string `json:"next"`
+		Prev string `json:"prev"`
+	} `json:"links"`
+}
+
+func (l list) ids() []string {
+	ids := make([]string, len(l.Data))
+	for i, w := range l.Data {
+		ids[i] = w.ID
+	}
+	return ids
+}
+
+func decode[T any](t *testing.T, body []byte) T {
+	t.Helper()
+	var out T
+	require.NoError(t, json.Unmarshal(body, &out))
+	return out
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		dbMockFn   func(db *mocks.Storer)
+		wantStatus int
+		check      func(t *testing.T, body []byte)
+	}{
+		{
+			name: "GetWallpaper",
+			path: "/wallpapers/PresDayDC",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("Get", mock.Anything, "PresDayDC").Return(&store.WallpaperWithTags{Wallpaper: presDay}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "PresDayDC", decode[map[string]any](t, body)["id"])
+			},
+		},
+		{
+			name: "GetWallpaper_ByOldID",
+			path: "/wallpapers/1234",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("GetByOldID", mock.Anything, 1234).Return(&store.WallpaperWithTags{Wallpaper: presDay}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "GetWallpaper_Localized",
+			path: "/wallpapers/PresDayDC?lang=ja",
+			dbMockFn: func(db *mocks.Storer) {
+				w := presDay
+				w.Localized = map[string]store.Localization{"en": {Title: "Washington Monument"}, "ja": {Title: "ワシントン記念塔"}}
+				db.On("Get", mock.Anything, "PresDayDC").Return(&store.WallpaperWithTags{Wallpaper: w}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				res := decode[map[string]any](t, body)
+				assert.Equal(t, "ワシントン記念塔", res["title"])
+				assert.Equal(t, "ja", res["lang"])
+			},
+		},
+		{
+			name: "GetWallpaper_NotFound",
+			path: "/wallpapers/Missing",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("Get", mock.Anything, "Missing").Return(nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "GetWallpapers_Filters",
+			path: "/wallpapers?market=ja-JP&orientation=portrait&limit=2",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("List", mock.Anything, store.ListQuery{Limit: 2, Market: "ja-JP", Orientation: "portrait"}).
+					Return([]store.Wallpaper{presDay, maui}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				res := decode[list](t, body)
+				assert.Equal(t, []string{"PresDayDC", "MauiWhale"}, res.ids())
+				assert.Equal(t, "/wallpapers?startAfterDate=20230219&startAfterID=MauiWhale&market=ja-JP&orientation=portrait", res.Links.Next)
+				assert.Empty(t, res.Links.Prev)
+			},
+		},
+		{
+			name: "GetWallpapers_Safe",
+			path: "/wallpapers?safe=1&startAfterDate=20230221&startAfterID=Next",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("List", mock.Anything, store.ListQuery{Limit: handler.DefaultPageSize, StartAfterDate: 20230221, StartAfterID: "Next"}).
+					Return([]store.Wallpaper{presDay, racy}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				res := decode[list](t, body)
+				assert.Equal(t, []string{"PresDayDC"}, res.ids())
+				// links follow the unfiltered page
+				assert.Equal(t, "/wallpapers?startAfterDate=20230218&startAfterID=Racy&safe=1", res.Links.Next)
+				assert.Equal(t, "/wallpapers?startAfterDate=20230220&startAfterID=PresDayDC&prev=1&safe=1", res.Links.Prev)
+			},
+		},
+		{
+			name: "GetWallpapers_NotFound",
+			path: "/wallpapers",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("List", mock.Anything, store.ListQuery{Limit: handler.DefaultPageSize}).Return(nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "GetWallpapers_InvalidOrientation",
+			path:       "/wallpapers?orientation=sideways",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GetWallpapersByCountry",
+			path: "/countries/us/wallpapers",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("List", mock.Anything, store.ListQuery{Limit: handler.DefaultPageSize, Country: "US"}).
+					Return([]store.Wallpaper{presDay}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				res := decode[list](t, body)
+				assert.Equal(t, []string{"PresDayDC"}, res.ids())
+				// a short page is the last one
+				assert.Empty(t, res.Links.Next)
+			},
+		},
+		{
+			name: "GetWallpapersByLandmark_Safe",
+			path: "/landmarks/eiffel-tower/wallpapers?safe=1",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("List", mock.Anything, store.ListQuery{Limit: handler.DefaultPageSize, Landmark: "eiffel-tower"}).
+					Return([]store.Wallpaper{racy, maui}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, []string{"MauiWhale"}, decode[list](t, body).ids())
+			},
+		},
+		{
+			name: "GetWallpapersByPhotographer_NotFound",
+			path: "/photographers/someone/wallpapers",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("List", mock.Anything, store.ListQuery{Limit: handler.DefaultPageSize, Photographer: "someone"}).Return(nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "GetPhotographers",
+			path: "/photographers?limit=5",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("ListPhotographers", mock.Anything, 5).
+					Return([]store.Photographer{{Slug: "aevanstock", Name: "AevanStock", Agency: "Shutterstock", Count: 3}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				res := decode[[]map[string]any](t, body)
+				require.Len(t, res, 1)
+				assert.Equal(t, "aevanstock", res[0]["slug"])
+			},
+		},
+		{
+			name: "GetWallpapersNear_Defaults",
+			path: "/wallpapers/near?lat=38.89&lng=-77.04",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("ListNear", mock.Anything, 38.89, -77.04, handler.DefaultRadius, handler.DefaultPageSize, false).
+					Return([]store.Wallpaper{presDay}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "GetWallpapersNear_Safe",
+			path: "/wallpapers/near?lat=38.89&lng=-77.04&radius=10&limit=5&safe=1",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("ListNear", mock.Anything, 38.89, -77.04, 10.0, 5, true).Return(nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "GetWallpapersNear_InvalidLatitude",
+			path:       "/wallpapers/near?lat=91&lng=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GetWallpapersNear_MissingLatitude",
+			path:       "/wallpapers/near?lng=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GetWallpaperImage_NotFound",
+			path: "/wallpapers/Missing/image",
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("Get", mock.Anything, "Missing").Return(nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "GetWallpaperImage_InvalidWidth",
+			path:       "/wallpapers/PresDayDC/image?w=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GetJobs_NoToken",
+			path:       "/admin/jobs",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "GetJobs_WrongToken",
+			path:       "/admin/jobs",
+			token:      "guess",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "GetJobs",
+			path:  "/admin/jobs?limit=5&startAfterID=20230220",
+			token: adminToken,
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("ListJobs", mock.Anything, 5, "20230220").
+					Return([]store.Job{{ID: "20230219", Type: "update", Trigger: "pubsub", Status: "succeeded"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				res := decode[[]map[string]any](t, body)
+				require.Len(t, res, 1)
+				assert.Equal(t, "20230219", res[0]["id"])
+			},
+		},
+		{
+			name:  "GetJob_NotFound",
+			path:  "/admin/jobs/missing",
+			token: adminToken,
+			dbMockFn: func(db *mocks.Storer) {
+				db.On("GetJob", mock.Anything, "missing").Return(nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewStorer(t)
+			if tt.dbMockFn != nil {
+				tt.dbMockFn(db)
+			}
+			server := newServer(t, db)
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode, string(body))
+			if tt.check != nil {
+				tt.check(t, body)
+			}
+		})
+	}
+}
+
+func TestGetWallpaperImage(t *testing.T) {
+	var src bytes.Buffer
+	require.NoError(t, jpeg.Encode(&src, image.NewRGBA(image.Rect(0, 0, 160, 90)), nil))
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "OHR.PresDayDC_1920x1080.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(src.Bytes())
+	}))
+	t.Cleanup(source.Close)
+
+	db := mocks.NewStorer(t)
+	found := presDay
+	found.URLBase, found.Resolutions = source.URL+"/th?id=OHR.PresDayDC", []string{"1920x1080"}
+	gone := maui
+	gone.URLBase, gone.Resolutions = source.URL+"/th?id=OHR.MauiWhale", []string{"1920x1080"}
+	db.On("Get", mock.Anything, "PresDayDC").Return(&store.WallpaperWithTags{Wallpaper: found}, nil)
+	db.On("Get", mock.Anything, "MauiWhale").Return(&store.WallpaperWithTags{Wallpaper: gone}, nil)
+	server := newServer(t, db)
+
+	res, err := http.Get(server.URL + "/wallpapers/PresDayDC/image?w=80&format=png")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+	assert.Equal(t, handler.ImageCacheControl, res.Header.Get("Cache-Control"))
+
+	// Bing no longer has the source
+	res, err = http.Get(server.URL + "/wallpapers/MauiWhale/image?w=80")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+}
@@ -0,0 +1,27 @@
+// Package resolution parses the names of Bing's resolution variants, shared by the API and the updater.
+package resolution
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Size parses a resolution such as "1920x1200" or "UHD".
+func Size(res string) (width, height int, ok bool) {
+	if res == "UHD" {
+		return 3840, 2160, true
+	}
+	w, h, found := strings.Cut(res, "x")
+	if !found {
+		return 0, 0, false
+	}
+	width, err := strconv.Atoi(w)
+	if err != nil {
+		return 0, 0, false
+	}
+	height, err = strconv.Atoi(h)
+	if err != nil {
+		return 0, 0, false
+	}
+	return width, height, true
+}
@@ -0,0 +1,22 @@
+package resolution_test
+
+import (
+	"testing"
+
+	"api/internal/resolution"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSize(t *testing.T) {
+	w, h, ok := resolution.Size("UHD")
+	assert.True(t, ok)
+	assert.Equal(t, []int{3840, 2160}, []int{w, h})
+
+	w, h, ok = resolution.Size("1080x1920")
+	assert.True(t, ok)
+	assert.Equal(t, []int{1080, 1920}, []int{w, h})
+
+	_, _, ok = resolution.Size("large")
+	assert.False(t, ok)
+}
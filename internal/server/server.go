@@ -20,9 +20,17 @@ func New(port string, h http.Handler) http.Server {
 
 	return http.Server{
 		Addr:         ":" + port,
-		Handler:      cors.Handler(h),
+		Handler:      cors.Handler(varyLanguage(h)),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 }
+
+// varyLanguage marks responses as depending on Accept-Language, since titles are localized.
+func varyLanguage(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+		h.ServeHTTP(w, r)
+	})
+}
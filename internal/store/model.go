@@ -1,7 +1,15 @@
 package store
 
 import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
 	"api/internal/api"
+	"api/internal/resolution"
+
+	"golang.org/x/text/language"
 )
 
 type Wallpaper struct {
@@ -12,17 +20,387 @@ type Wallpaper struct {
 	Market    string   `json:"market" firestore:"market"`
 	URLBase   string   `json:"urlBase" firestore:"urlBase"`
 	Colors    []string `json:"colors,omitempty" firestore:"colors,omitempty"` // Hex strings like "#4A90D9"
+
+	Localized  map[string]Localization `json:"localized,omitempty" firestore:"localized,omitempty"` // Keyed by language, e.g. "ja"
+	SafeSearch *SafeSearch             `json:"safeSearch,omitempty" firestore:"safeSearch,omitempty"`
+
+	Place   string  `json:"place,omitempty" firestore:"place,omitempty"`
+	Lat     float64 `json:"lat,omitempty" firestore:"lat,omitempty"`
+	Lng     float64 `json:"lng,omitempty" firestore:"lng,omitempty"`
+	Country string  `json:"country,omitempty" firestore:"country,omitempty"` // ISO 3166-1 alpha-2 code, e.g. "US"
+
+	Photographer     string `json:"photographer,omitempty" firestore:"photographer,omitempty"`
+	PhotographerSlug string `json:"photographerSlug,omitempty" firestore:"photographerSlug,omitempty"`
+	Agency           string `json:"agency,omitempty" firestore:"agency,omitempty"`
+
+	Headline      string `json:"headline,omitempty" firestore:"headline,omitempty"`
+	CopyrightLink string `json:"copyrightLink,omitempty" firestore:"copyrightLink,omitempty"` // Bing search about the wallpaper
+
+	BlurHash string `json:"blurHash,omitempty" firestore:"blurHash,omitempty"`
+	LQIP     string `json:"lqip,omitempty" firestore:"lqip,omitempty"` // Data URI of a tiny JPEG preview
+
+	Resolutions []string           `json:"resolutions,omitempty" firestore:"resolutions,omitempty"` // Variants Bing publishes, largest first
+	Mirrors     map[string]Mirror  `json:"mirrors,omitempty" firestore:"mirrors,omitempty"`         // Keyed by resolution
+	Variants    map[string]Variant `json:"variants,omitempty" firestore:"variants,omitempty"`       // Keyed by resolution
+
+	// Set by Localize
+	Lang              string `json:"lang,omitempty" firestore:"-"`
+	MachineTranslated bool   `json:"machineTranslated,omitempty" firestore:"-"`
+}
+
+// Mirror is a copy of a resolution variant in our own storage.
+type Mirror struct {
+	URL    string `json:"url" firestore:"url"`
+	SHA256 string `json:"sha256" firestore:"sha256"`
+	Size   int    `json:"size" firestore:"size"`
+}
+
+// Variant is the measured size of a resolution variant.
+type Variant struct {
+	Width       int     `json:"width" firestore:"width"`
+	Height      int     `json:"height" firestore:"height"`
+	AspectRatio float64 `json:"aspectRatio" firestore:"aspectRatio"`
+	Size        int     `json:"size" firestore:"size"`
+	Quality     int     `json:"quality,omitempty" firestore:"quality,omitempty"`
+}
+
+// Photographer is a photographer credited on wallpapers and how many they are credited on.
+type Photographer struct {
+	Slug   string `json:"slug" firestore:"slug"`
+	Name   string `json:"name" firestore:"name"`
+	Agency string `json:"agency,omitempty" firestore:"agency,omitempty"`
+	Count  int    `json:"count" firestore:"count"`
+}
+
+func PhotographersToAPI(p []Photographer) []api.Photographer {
+	res := make([]api.Photographer, len(p))
+	for i, v := range p {
+		res[i] = api.Photographer{
+			Slug:  v.Slug,
+			Name:  v.Name,
+			Count: v.Count,
+		}
+		if v.Agency != "" {
+			res[i].Agency = api.NewOptString(v.Agency)
+		}
+	}
+	return res
+}
+
+// Job is an updater run, as recorded by the updater.
+type Job struct {
+	ID        string         `firestore:"id"`
+	Type      string         `firestore:"type"`
+	Trigger   string         `firestore:"trigger"`
+	Status    string         `firestore:"status"`
+	StartedAt time.Time      `firestore:"startedAt"`
+	EndedAt   *time.Time     `firestore:"endedAt,omitempty"`
+	Markets   map[string]int `firestore:"markets,omitempty"`
+	New       []string       `firestore:"new"`
+	Updated   []string       `firestore:"updated"`
+	Failed    []JobFailure   `firestore:"failed"`
+	Error     string         `firestore:"error,omitempty"`
+}
+
+// JobFailure is an image a job failed to store.
+type JobFailure struct {
+	ID    string `firestore:"id"`
+	Error string `firestore:"error"`
+}
+
+func (j Job) ToAPI() api.Job {
+	res := api.Job{
+		ID:        j.ID,
+		Type:      j.Type,
+		Trigger:   j.Trigger,
+		Status:    api.JobStatus(j.Status),
+		StartedAt: j.StartedAt,
+		New:       nonNil(j.New),
+		Updated:   nonNil(j.Updated),
+		Failed:    make([]api.JobFailure, len(j.Failed)),
+	}
+	if j.EndedAt != nil {
+		res.EndedAt = api.NewOptDateTime(*j.EndedAt)
+	}
+	if len(j.Markets) > 0 {
+		res.Markets = api.NewOptJobMarkets(j.Markets)
+	}
+	for i, f := range j.Failed {
+		res.Failed[i] = api.JobFailure{ID: f.ID, Error: f.Error}
+	}
+	if j.Error != "" {
+		res.Error = api.NewOptString(j.Error)
+	}
+	return res
+}
+
+// nonNil returns s, or an empty slice if it is nil, so that it is encoded as an empty array.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// Localization is a title, copyright and headline in one language.
+type Localization struct {
+	Title             string `json:"title" firestore:"title"`
+	Copyright         string `json:"copyright,omitempty" firestore:"copyright,omitempty"`
+	Headline          string `json:"headline,omitempty" firestore:"headline,omitempty"`
+	MachineTranslated bool   `json:"machineTranslated,omitempty" firestore:"machineTranslated,omitempty"`
+}
+
+// Localize returns the wallpaper with its title and copyright in the stored language that best matches prefs.
+// English is used when nothing matches, and the wallpaper is unchanged if it has no localized titles.
+func (w Wallpaper) Localize(prefs []language.Tag) Wallpaper {
+	if len(prefs) == 0 || len(w.Localized) == 0 {
+		return w
+	}
+
+	// the matcher falls back to the first supported language
+	langs := slices.Sorted(maps.Keys(w.Localized))
+	if i := slices.Index(langs, "en"); i > 0 {
+		langs = append([]string{"en"}, slices.Delete(langs, i, i+1)...)
+	}
+
+	supported := make([]language.Tag, len(langs))
+	for i, l := range langs {
+		supported[i] = language.Make(l)
+	}
+
+	_, i, _ := language.NewMatcher(supported).Match(prefs...)
+	l := w.Localized[langs[i]]
+
+	w.Title = l.Title
+	if l.Copyright != "" {
+		w.Copyright = l.Copyright
+	}
+	// the stored headline is in the language of the market, so it is dropped rather than mixed with another language
+	if lang, _, _ := strings.Cut(w.Market, "-"); l.Headline != "" || !strings.EqualFold(lang, langs[i]) {
+		w.Headline = l.Headline
+	}
+	w.Lang = langs[i]
+	w.MachineTranslated = l.MachineTranslated
+	return w
+}
+
+// SafeSearch holds the likelihood of each kind of sensitive content, e.g. "VERY_UNLIKELY".
+type SafeSearch struct {
+	Adult    string `json:"adult" firestore:"adult"`
+	Spoof    string `json:"spoof" firestore:"spoof"`
+	Medical  string `json:"medical" firestore:"medical"`
+	Violence string `json:"violence" firestore:"violence"`
+	Racy     string `json:"racy" firestore:"racy"`
+}
+
+// Safe reports whether the wallpaper is unlikely to contain adult, racy or violent content.
+// Wallpapers that were never checked are considered safe.
+func (w Wallpaper) Safe() bool {
+	if w.SafeSearch == nil {
+		return true
+	}
+	for _, l := range []string{w.SafeSearch.Adult, w.SafeSearch.Racy, w.SafeSearch.Violence} {
+		if l == "LIKELY" || l == "VERY_LIKELY" {
+			return false
+		}
+	}
+	return true
+}
+
+// Landmark is a recognised place in a wallpaper.
+type Landmark struct {
+	Name  string  `json:"name" firestore:"name"`
+	Score float32 `json:"score" firestore:"score"`
+	Lat   float64 `json:"lat,omitempty" firestore:"lat,omitempty"`
+	Lng   float64 `json:"lng,omitempty" firestore:"lng,omitempty"`
+}
+
+// WebEntity is an entity a wallpaper is associated with on the web.
+type WebEntity struct {
+	Description string  `json:"description" firestore:"description"`
+	Score       float32 `json:"score" firestore:"score"`
+}
+
+// Appearance is a market and date a wallpaper was published on.
+type Appearance struct {
+	Market    string `json:"market" firestore:"market"`
+	Date      int    `json:"date" firestore:"date"`
+	Title     string `json:"title,omitempty" firestore:"title,omitempty"`
+	Copyright string `json:"copyright,omitempty" firestore:"copyright,omitempty"`
+	Headline  string `json:"headline,omitempty" firestore:"headline,omitempty"`
 }
 
 type WallpaperWithTags struct {
 	Wallpaper
 
-	Tags map[string]float32 `json:"tags" firestore:"tags"`
+	Tags        map[string]float32 `json:"tags" firestore:"tags"`
+	Appearances []Appearance       `json:"appearances,omitempty" firestore:"appearances,omitempty"`
+	Landmarks   []Landmark         `json:"landmarks,omitempty" firestore:"landmarks,omitempty"`
+	WebEntities []WebEntity        `json:"webEntities,omitempty" firestore:"webEntities,omitempty"`
+}
+
+func AppearancesToAPI(a []Appearance) []api.Appearance {
+	res := make([]api.Appearance, len(a))
+	for i, v := range a {
+		res[i] = api.Appearance{
+			Market: v.Market,
+			Date:   api.Date(v.Date),
+		}
+		if v.Title != "" {
+			res[i].Title = api.NewOptString(v.Title)
+		}
+		if v.Copyright != "" {
+			res[i].Copyright = api.NewOptString(v.Copyright)
+		}
+		if v.Headline != "" {
+			res[i].Headline = api.NewOptString(v.Headline)
+		}
+	}
+	return res
+}
+
+func LandmarksToAPI(l []Landmark) []api.Landmark {
+	res := make([]api.Landmark, len(l))
+	for i, v := range l {
+		res[i] = api.Landmark{
+			Name:  v.Name,
+			Score: v.Score,
+		}
+		if v.Lat != 0 || v.Lng != 0 {
+			res[i].Lat = api.NewOptFloat64(v.Lat)
+			res[i].Lng = api.NewOptFloat64(v.Lng)
+		}
+	}
+	return res
+}
+
+func WebEntitiesToAPI(e []WebEntity) []api.WebEntity {
+	res := make([]api.WebEntity, len(e))
+	for i, v := range e {
+		res[i] = api.WebEntity{
+			Description: v.Description,
+			Score:       v.Score,
+		}
+	}
+	return res
+}
+
+func SafeSearchToAPI(s *SafeSearch) api.OptSafeSearch {
+	if s == nil {
+		return api.OptSafeSearch{}
+	}
+	return api.NewOptSafeSearch(api.SafeSearch{
+		Adult:    s.Adult,
+		Spoof:    s.Spoof,
+		Medical:  s.Medical,
+		Violence: s.Violence,
+		Racy:     s.Racy,
+	})
+}
+
+func LocalizedToAPI(l map[string]Localization) api.WallpaperWithTagsLocalized {
+	res := make(api.WallpaperWithTagsLocalized, len(l))
+	for k, v := range l {
+		loc := api.Localization{
+			Title:             v.Title,
+			MachineTranslated: api.NewOptBool(v.MachineTranslated),
+		}
+		if v.Copyright != "" {
+			loc.Copyright = api.NewOptString(v.Copyright)
+		}
+		if v.Headline != "" {
+			loc.Headline = api.NewOptString(v.Headline)
+		}
+		res[k] = loc
+	}
+	return res
+}
+
+// LocationToAPI returns where the wallpaper was taken, if known.
+func (w Wallpaper) LocationToAPI() api.OptLocation {
+	if w.Place == "" {
+		return api.OptLocation{}
+	}
+	loc := api.Location{
+		Place: w.Place,
+		Lat:   w.Lat,
+		Lng:   w.Lng,
+	}
+	if w.Country != "" {
+		loc.Country = api.NewOptString(w.Country)
+	}
+	return api.NewOptLocation(loc)
+}
+
+// ImagesToAPI returns the wallpaper's resolution variants, served from our mirror where possible and otherwise from Bing.
+// Mirrored variants that Bing no longer publishes come last.
+func (w Wallpaper) ImagesToAPI() []api.WallpaperImage {
+	resolutions := slices.Clone(w.Resolutions)
+	for _, res := range slices.Sorted(maps.Keys(w.Mirrors)) {
+		if !slices.Contains(resolutions, res) {
+			resolutions = append(resolutions, res)
+		}
+	}
+
+	res := make([]api.WallpaperImage, len(resolutions))
+	for i, r := range resolutions {
+		res[i] = api.WallpaperImage{Resolution: r, URL: w.URLBase + "_" + r + ".jpg"}
+		if m, ok := w.Mirrors[r]; ok {
+			res[i].URL = m.URL
+			res[i].Mirrored = true
+			res[i].SHA256 = api.NewOptString(m.SHA256)
+			res[i].Size = api.NewOptInt(m.Size)
+		}
+		if v, ok := w.Variants[r]; ok {
+			res[i].Width = api.NewOptInt(v.Width)
+			res[i].Height = api.NewOptInt(v.Height)
+			res[i].AspectRatio = api.NewOptFloat64(v.AspectRatio)
+			res[i].Size = api.NewOptInt(v.Size)
+			if v.Quality != 0 {
+				res[i].Quality = api.NewOptInt(v.Quality)
+			}
+		}
+	}
+	return res
+}
+
+// Source returns the URL of the smallest variant at least width by height in the same orientation,
+// or the largest one if none is big enough, along with the checksum of its content if it is mirrored.
+// A zero width or height matches any.
+func (w Wallpaper) Source(width, height int) (url, checksum string) {
+	portrait := height > width && width != 0
+	var best, largest *api.WallpaperImage
+	var bestArea, largestArea int
+
+	images := w.ImagesToAPI()
+	for i, img := range images {
+		dx, dy, ok := resolution.Size(img.Resolution)
+		if !ok || (dy > dx) != portrait {
+			continue
+		}
+		if area := dx * dy; area > largestArea {
+			largest, largestArea = &images[i], area
+		}
+		if dx >= width && dy >= height && (best == nil || dx*dy < bestArea) {
+			best, bestArea = &images[i], dx*dy
+		}
+	}
+
+	switch {
+	case best != nil:
+		return best.URL, best.SHA256.Or("")
+	case largest != nil:
+		return largest.URL, largest.SHA256.Or("")
+	default:
+		// documents from before resolutions were probed
+		return w.URLBase + "_1920x1080.jpg", ""
+	}
 }
 
-func ToAPI(w []Wallpaper) []api.Wallpaper {
+func ToAPI(w []Wallpaper, prefs []language.Tag) []api.Wallpaper {
 	res := make([]api.Wallpaper, len(w))
 	for i, v := range w {
+		v = v.Localize(prefs)
 		res[i] = api.Wallpaper{
 			ID:        api.ID(v.ID),
 			Title:     v.Title,
@@ -31,6 +409,31 @@ func ToAPI(w []Wallpaper) []api.Wallpaper {
 			Market:    v.Market,
 			UrlBase:   v.URLBase,
 			Colors:    v.Colors,
+			Location:  v.LocationToAPI(),
+			Images:    v.ImagesToAPI(),
+		}
+		if v.BlurHash != "" {
+			res[i].BlurHash = api.NewOptString(v.BlurHash)
+		}
+		if v.LQIP != "" {
+			res[i].Lqip = api.NewOptString(v.LQIP)
+		}
+		if v.Photographer != "" {
+			res[i].Photographer = api.NewOptString(v.Photographer)
+			res[i].PhotographerSlug = api.NewOptString(v.PhotographerSlug)
+		}
+		if v.Agency != "" {
+			res[i].Agency = api.NewOptString(v.Agency)
+		}
+		if v.Headline != "" {
+			res[i].Headline = api.NewOptString(v.Headline)
+		}
+		if v.CopyrightLink != "" {
+			res[i].SearchUrl = api.NewOptString(v.CopyrightLink)
+		}
+		if v.Lang != "" {
+			res[i].Lang = api.NewOptString(v.Lang)
+			res[i].MachineTranslated = api.NewOptBool(v.MachineTranslated)
 		}
 	}
 	return res
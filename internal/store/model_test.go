@@ -0,0 +1,169 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"api/internal/api"
+	"api/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestWallpaper_Localize(t *testing.T) {
+	w := store.Wallpaper{
+		ID:        "PresDayDC",
+		Title:     "Washington Monument",
+		Copyright: "AevanStock/Shutterstock",
+		Localized: map[string]store.Localization{
+			"en": {Title: "Washington Monument", Copyright: "AevanStock/Shutterstock"},
+			"ja": {Title: "ワシントン記念塔"},
+			"de": {Title: "Washington-Denkmal", MachineTranslated: true},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		prefs     string
+		wantTitle string
+		wantLang  string
+		wantMT    bool
+	}{
+		{name: "NoPreference", wantTitle: "Washington Monument"},
+		{name: "Exact", prefs: "ja", wantTitle: "ワシントン記念塔", wantLang: "ja"},
+		{name: "Region", prefs: "de-AT,en;q=0.5", wantTitle: "Washington-Denkmal", wantLang: "de", wantMT: true},
+		{name: "FallsBackToEnglish", prefs: "ko", wantTitle: "Washington Monument", wantLang: "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prefs []language.Tag
+			if tt.prefs != "" {
+				prefs, _, _ = language.ParseAcceptLanguage(tt.prefs)
+			}
+
+			got := w.Localize(prefs)
+			assert.Equal(t, tt.wantTitle, got.Title)
+			assert.Equal(t, "AevanStock/Shutterstock", got.Copyright)
+			assert.Equal(t, tt.wantLang, got.Lang)
+			assert.Equal(t, tt.wantMT, got.MachineTranslated)
+		})
+	}
+}
+
+func TestWallpaper_Safe(t *testing.T) {
+	assert.True(t, store.Wallpaper{}.Safe())
+	assert.True(t, store.Wallpaper{SafeSearch: &store.SafeSearch{Adult: "VERY_UNLIKELY", Racy: "POSSIBLE", Medical: "LIKELY"}}.Safe())
+	assert.False(t, store.Wallpaper{SafeSearch: &store.SafeSearch{Adult: "VERY_UNLIKELY", Racy: "LIKELY"}}.Safe())
+	assert.False(t, store.Wallpaper{SafeSearch: &store.SafeSearch{Violence: "VERY_LIKELY"}}.Safe())
+}
+
+func TestWallpaper_ImagesToAPI(t *testing.T) {
+	w := store.Wallpaper{
+		URLBase:     "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773",
+		Resolutions: []string{"UHD", "1920x1200"},
+		Mirrors: map[string]store.Mirror{
+			"1920x1200": {URL: "https://img.example.com/PresDayDC/1920x1200.jpg", SHA256: "abc", Size: 100},
+			"1920x1080": {URL: "https://img.example.com/PresDayDC/1920x1080.jpg", SHA256: "def", Size: 90},
+		},
+		Variants: map[string]store.Variant{
+			"UHD": {Width: 3840, Height: 2160, AspectRatio: 1.778, Size: 1200, Quality: 85},
+		},
+	}
+
+	assert.Equal(t, []api.WallpaperImage{
+		{
+			Resolution: "UHD", URL: "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773_UHD.jpg",
+			Size: api.NewOptInt(1200), Width: api.NewOptInt(3840), Height: api.NewOptInt(2160),
+			AspectRatio: api.NewOptFloat64(1.778), Quality: api.NewOptInt(85),
+		},
+		{
+			Resolution: "1920x1200", URL: "https://img.example.com/PresDayDC/1920x1200.jpg", Mirrored: true,
+			SHA256: api.NewOptString("abc"), Size: api.NewOptInt(100),
+		},
+		{
+			Resolution: "1920x1080", URL: "https://img.example.com/PresDayDC/1920x1080.jpg", Mirrored: true,
+			SHA256: api.NewOptString("def"), Size: api.NewOptInt(90),
+		},
+	}, w.ImagesToAPI())
+}
+
+func TestWallpaper_Source(t *testing.T) {
+	w := store.Wallpaper{
+		URLBase:     "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773",
+		Resolutions: []string{"UHD", "1920x1200", "1920x1080", "1080x1920"},
+		Mirrors: map[string]store.Mirror{
+			"1920x1200": {URL: "https://img.example.com/PresDayDC/1920x1200.jpg", SHA256: "abc"},
+		},
+	}
+
+	url, checksum := w.Source(400, 0)
+	assert.Equal(t, "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773_1920x1080.jpg", url)
+	assert.Empty(t, checksum)
+
+	url, checksum = w.Source(1920, 1200)
+	assert.Equal(t, "https://img.example.com/PresDayDC/1920x1200.jpg", url)
+	assert.Equal(t, "abc", checksum)
+
+	url, _ = w.Source(2560, 0)
+	assert.Equal(t, "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773_UHD.jpg", url)
+
+	url, _ = w.Source(5000, 0)
+	assert.Equal(t, "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773_UHD.jpg", url)
+
+	url, _ = w.Source(300, 600)
+	assert.Equal(t, "https://www.bing.com/th?id=OHR.PresDayDC_EN-US2054662773_1080x1920.jpg", url)
+
+	url, _ = store.Wallpaper{URLBase: "https://www.bing.com/th?id=OHR.Old"}.Source(0, 0)
+	assert.Equal(t, "https://www.bing.com/th?id=OHR.Old_1920x1080.jpg", url)
+}
+
+func TestWallpaper_LocalizeHeadline(t *testing.T) {
+	w := store.Wallpaper{
+		Title:    "Washington Monument",
+		Market:   "en-US",
+		Headline: "Happy Presidents Day!",
+		Localized: map[string]store.Localization{
+			"en": {Title: "Washington Monument"},
+			"ja": {Title: "ワシントン記念塔", Headline: "大統領の日"},
+			"de": {Title: "Washington-Denkmal", MachineTranslated: true},
+		},
+	}
+
+	assert.Equal(t, "Happy Presidents Day!", w.Localize([]language.Tag{language.English}).Headline)
+	assert.Equal(t, "大統領の日", w.Localize([]language.Tag{language.Japanese}).Headline)
+	assert.Empty(t, w.Localize([]language.Tag{language.German}).Headline)
+}
+
+func TestJob_ToAPI(t *testing.T) {
+	started := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	ended := started.Add(time.Minute)
+
+	assert.Equal(t, api.Job{
+		ID:        "20250301T120000Z-a1b2c3",
+		Type:      "update",
+		Trigger:   "schedule",
+		Status:    api.JobStatusPartial,
+		StartedAt: started,
+		EndedAt:   api.NewOptDateTime(ended),
+		Markets:   api.NewOptJobMarkets(api.JobMarkets{"en-US": 8}),
+		New:       []string{"PresDayDC"},
+		Updated:   []string{},
+		Failed:    []api.JobFailure{{ID: "MauiWhale", Error: "failed to download image"}},
+	}, store.Job{
+		ID:        "20250301T120000Z-a1b2c3",
+		Type:      "update",
+		Trigger:   "schedule",
+		Status:    "partial",
+		StartedAt: started,
+		EndedAt:   &ended,
+		Markets:   map[string]int{"en-US": 8},
+		New:       []string{"PresDayDC"},
+		Failed:    []store.JobFailure{{ID: "MauiWhale", Error: "failed to download image"}},
+	}.ToAPI())
+
+	running := store.Job{ID: "20250301T120000Z-a1b2c3", Status: "running", StartedAt: started}.ToAPI()
+	assert.False(t, running.EndedAt.Set)
+	assert.False(t, running.Markets.Set)
+	assert.Equal(t, []string{}, running.New)
+}
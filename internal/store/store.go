@@ -1,19 +1,28 @@
 package store
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"slices"
 
+	"api/internal/collections"
+	"api/internal/geo"
+
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-// TagPageSize is the number of wallpapers returned when listing by tag.
-const TagPageSize = 36
+const (
+	// TagPageSize is the number of wallpapers returned when listing by tag.
+	TagPageSize = 36
+
+	// nearCellLimit bounds the wallpapers read from each geohash cell when listing near a point.
+	nearCellLimit = 500
+)
 
 type Storer interface {
 	Get(ctx context.Context, id string) (*WallpaperWithTags, error)
@@ -21,6 +30,10 @@ type Storer interface {
 	GetTags(ctx context.Context) (map[string]int, error)
 	List(ctx context.Context, q ListQuery) ([]Wallpaper, error)
 	ListByTag(ctx context.Context, tag string, after float64) ([]Wallpaper, float64, error)
+	ListNear(ctx context.Context, lat, lng, radius float64, limit int, safe bool) ([]Wallpaper, error)
+	ListPhotographers(ctx context.Context, limit int) ([]Photographer, error)
+	GetJob(ctx context.Context, id string) (*Job, error)
+	ListJobs(ctx context.Context, limit int, startAfterID string) ([]Job, error)
 }
 
 type ListQuery struct {
@@ -28,6 +41,12 @@ type ListQuery struct {
 	StartAfterDate int
 	StartAfterID   string
 	Reverse        bool
+	Market         string // Only wallpapers that appeared in this market
+	Landmark       string // Only wallpapers showing this landmark, by slug; cannot be combined with Market
+	Country        string // Only wallpapers taken in this country, e.g. "US"
+	Photographer   string // Only wallpapers by this photographer, by slug
+	Agency         string // Only wallpapers from this agency, by slug
+	Orientation    string // Only wallpapers with a "landscape" or "portrait" variant
 }
 
 func New(collection string, firestore *firestore.Client) Store {
@@ -102,6 +121,30 @@ func (s *Store) GetTags(ctx context.Context) (map[string]int, error) {
 func (s *Store) List(ctx context.Context, q ListQuery) ([]Wallpaper, error) {
 	query := s.firestore.Collection(s.collection).Limit(q.Limit)
 
+	if q.Market != "" {
+		query = query.Where("markets", "array-contains", q.Market)
+	}
+
+	if q.Landmark != "" {
+		query = query.Where("landmarkSlugs", "array-contains", q.Landmark)
+	}
+
+	if q.Country != "" {
+		query = query.Where("country", "==", q.Country)
+	}
+
+	if q.Photographer != "" {
+		query = query.Where("photographerSlug", "==", q.Photographer)
+	}
+
+	if q.Agency != "" {
+		query = query.Where("agencySlug", "==", q.Agency)
+	}
+
+	if q.Orientation != "" {
+		query = query.Where(q.Orientation, "==", true)
+	}
+
 	if q.Reverse {
 		query = query.
 			OrderBy("date", firestore.Asc).
@@ -165,6 +208,123 @@ func (s *Store) ListByTag(ctx context.Context, tag string, after float64) ([]Wal
 	return wallpapers, next, nil
 }
 
+// ListPhotographers returns up to limit photographers, most credited first.
+func (s *Store) ListPhotographers(ctx context.Context, limit int) ([]Photographer, error) {
+	dsnap, err := s.firestore.Collection(collections.Photographers).
+		OrderBy("count", firestore.Desc).
+		OrderBy("slug", firestore.Asc).
+		Limit(limit).
+		Documents(ctx).
+		GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	photographers := make([]Photographer, 0, len(dsnap))
+	for _, doc := range dsnap {
+		var p Photographer
+		if err := doc.DataTo(&p); err != nil {
+			return nil, err
+		}
+		photographers = append(photographers, p)
+	}
+
+	return photographers, nil
+}
+
+// GetJob returns an updater run, or nil if it doesn't exist.
+func (s *Store) GetJob(ctx context.Context, id string) (*Job, error) {
+	dsnap, err := s.firestore.Collection(collections.Jobs).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var j Job
+	if err := dsnap.DataTo(&j); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// ListJobs returns up to limit updater runs, latest first, started before startAfterID if it isn't empty.
+// Job IDs sort by start time.
+func (s *Store) ListJobs(ctx context.Context, limit int, startAfterID string) ([]Job, error) {
+	query := s.firestore.Collection(collections.Jobs).OrderBy("id", firestore.Desc)
+	if startAfterID != "" {
+		query = query.StartAfter(startAfterID)
+	}
+
+	dsnap, err := query.Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(dsnap))
+	for _, doc := range dsnap {
+		var j Job
+		if err := doc.DataTo(&j); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// ListNear returns up to limit wallpapers taken within radius km of a point, nearest first, only safe ones if safe is set.
+// Wallpapers are found by the geohash cells covering the circle, then filtered by their actual distance.
+// At most nearCellLimit wallpapers are read from each cell, so very large radii only see part of the collection.
+func (s *Store) ListNear(ctx context.Context, lat, lng, radius float64, limit int, safe bool) ([]Wallpaper, error) {
+	type near struct {
+		wallpaper Wallpaper
+		distance  float64
+	}
+
+	var found []near
+	seen := make(map[string]bool)
+	for _, cell := range geo.Cells(lat, lng, radius) {
+		dsnap, err := s.firestore.Collection(s.collection).
+			Where("geohash", ">=", cell).
+			Where("geohash", "<", cell+"~").
+			Limit(nearCellLimit).
+			Documents(ctx).
+			GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range dsnap {
+			if seen[doc.Ref.ID] {
+				continue
+			}
+			seen[doc.Ref.ID] = true
+
+			var wallpaper Wallpaper
+			if err := doc.DataTo(&wallpaper); err != nil {
+				return nil, err
+			}
+			if safe && !wallpaper.Safe() {
+				continue
+			}
+			if d := geo.Distance(lat, lng, wallpaper.Lat, wallpaper.Lng); d <= radius {
+				found = append(found, near{wallpaper, d})
+			}
+		}
+	}
+
+	slices.SortFunc(found, func(a, b near) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), cmp.Compare(b.wallpaper.Date, a.wallpaper.Date))
+	})
+
+	wallpapers := make([]Wallpaper, 0, min(limit, len(found)))
+	for _, n := range found[:min(limit, len(found))] {
+		wallpapers = append(wallpapers, n.wallpaper)
+	}
+	return wallpapers, nil
+}
+
 // extractTagScore safely extracts a tag's score from document data.
 // Returns 0 if the tag or score cannot be found.
 func extractTagScore(data map[string]any, tag string) float64 {
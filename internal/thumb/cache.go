@@ -0,0 +1,169 @@
+package thumb
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache keeps rendered images as files in a directory, removing the least recently used
+// once their total size exceeds the budget.
+type Cache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List               // Of *entry, most recently used first
+	entries map[string]*list.Element // Keyed by cache key
+}
+
+type entry struct {
+	key  string
+	size int64
+}
+
+// NewCache opens a cache in dir, creating it if needed and picking up images from previous runs,
+// which are ordered by their modification time. Temporary files left by interrupted writes are removed,
+// and other files are left alone.
+func NewCache(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{dir: dir, maxSize: maxSize, lru: list.New(), entries: make(map[string]*list.Element)}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type file struct {
+		name    string
+		size    int64
+		modTime time.Time
+	}
+	var existing []file
+	for _, f := range files {
+		info, err := f.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if strings.HasPrefix(f.Name(), tempPrefix) {
+			_ = os.Remove(filepath.Join(dir, f.Name()))
+			continue
+		}
+		if !isKey(f.Name()) {
+			continue
+		}
+		existing = append(existing, file{f.Name(), info.Size(), info.ModTime()})
+	}
+	slices.SortFunc(existing, func(a, b file) int { return b.modTime.Compare(a.modTime) })
+
+	for _, f := range existing {
+		c.entries[f.name] = c.lru.PushBack(&entry{key: f.name, size: f.size})
+		c.size += f.size
+	}
+	c.evict()
+
+	return c, nil
+}
+
+// tempPrefix starts the names of files being written.
+const tempPrefix = ".tmp-"
+
+// isKey reports whether name is a cache key, a hex encoded SHA-256 hash.
+func isKey(name string) bool {
+	b, err := hex.DecodeString(name)
+	return err == nil && len(b) == sha256.Size && name == hex.EncodeToString(b)
+}
+
+// Get returns a cached image, marking it as recently used.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	if ok {
+		c.lru.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(filepath.Join(c.dir, key)) // #nosec G304 -- keys are hex encoded hashes
+	if err != nil {
+		c.remove(key)
+		return nil, false
+	}
+
+	// keep the order across restarts
+	now := time.Now()
+	_ = os.Chtimes(filepath.Join(c.dir, key), now, now)
+	return b, true
+}
+
+// Put stores an image, evicting the least recently used ones if the cache is over budget.
+func (c *Cache) Put(key string, b []byte) error {
+	tmp, err := os.CreateTemp(c.dir, tempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, key)); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= el.Value.(*entry).size
+		c.lru.Remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(&entry{key: key, size: int64(len(b))})
+	c.size += int64(len(b))
+	c.evict()
+	return nil
+}
+
+// Size returns the total size of the cached images.
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *Cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= el.Value.(*entry).size
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// evict removes the least recently used images until the cache is within budget. c.mu must be held.
+func (c *Cache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		e := c.lru.Remove(c.lru.Back()).(*entry)
+		delete(c.entries, e.key)
+		c.size -= e.size
+		_ = os.Remove(filepath.Join(c.dir, e.key))
+	}
+}
@@ -0,0 +1,177 @@
+package thumb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"runtime"
+
+	"api/internal/fetch"
+
+	"golang.org/x/image/draw"
+)
+
+// jpegQuality is the quality of encoded JPEGs.
+const jpegQuality = 85
+
+// ErrSource is wrapped by errors about source images that couldn't be fetched or decoded.
+var ErrSource = errors.New("bad source image")
+
+// Fit is how an image is scaled when both a width and height are given.
+type Fit string
+
+const (
+	// Contain scales the image to fit inside the size, keeping its aspect ratio.
+	Contain Fit = "contain"
+	// Cover scales the image to fill the size, cropping around the center.
+	Cover Fit = "cover"
+)
+
+// Format is the encoding of a rendered image.
+type Format string
+
+const (
+	JPEG Format = "jpeg"
+	PNG  Format = "png"
+)
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	if f == PNG {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
+
+// Options describes a rendered image. A zero width or height follows the aspect ratio of the source,
+// and the source size is kept if both are zero.
+type Options struct {
+	Width  int
+	Height int
+	Fit    Fit
+	Format Format
+}
+
+// Renderer resizes and converts source images, caching the results.
+type Renderer struct {
+	Fetcher *fetch.Fetcher
+	Cache   *Cache // Optional
+
+	sem chan struct{} // Limits concurrent renders, since decoded wallpapers use a lot of memory
+}
+
+func NewRenderer(fetcher *fetch.Fetcher, cache *Cache) *Renderer {
+	return &Renderer{Fetcher: fetcher, Cache: cache, sem: make(chan struct{}, runtime.NumCPU())}
+}
+
+// Render returns the image at source rendered with opts, and an ETag for it.
+// version identifies the source content, such as its checksum, so that replaced sources aren't served from the cache.
+func (r *Renderer) Render(ctx context.Context, source, version string, opts Options) ([]byte, string, error) {
+	key := cacheKey(source, version, opts)
+	if r.Cache != nil {
+		if b, ok := r.Cache.Get(key); ok {
+			return b, etag(key), nil
+		}
+	}
+
+	select {
+	case r.sem <- struct{}{}:
+		defer func() { <-r.sem }()
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+
+	src, err := r.Fetcher.Get(ctx, source)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: failed to fetch: %w", ErrSource, err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: failed to decode: %w", ErrSource, err)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, Resize(img, opts), opts.Format); err != nil {
+		return nil, "", err
+	}
+
+	if r.Cache != nil {
+		if err := r.Cache.Put(key, buf.Bytes()); err != nil {
+			return nil, "", err
+		}
+	}
+	return buf.Bytes(), etag(key), nil
+}
+
+// Resize scales img to the size in opts, returning it unchanged if it already has that size.
+func Resize(img image.Image, opts Options) image.Image {
+	b := img.Bounds()
+	w, h := opts.Width, opts.Height
+	switch {
+	case w == 0 && h == 0:
+		return img
+	case h == 0:
+		h = max(1, b.Dy()*w/b.Dx())
+	case w == 0:
+		w = max(1, b.Dx()*h/b.Dy())
+	}
+
+	// the part of the source that is scaled, which for cover is the center with the target's aspect ratio
+	sr := b
+	switch {
+	case opts.Fit == Cover && opts.Width != 0 && opts.Height != 0:
+		if b.Dx()*h > b.Dy()*w {
+			cw := b.Dy() * w / h
+			sr.Min.X += (b.Dx() - cw) / 2
+			sr.Max.X = sr.Min.X + cw
+		} else {
+			ch := b.Dx() * h / w
+			sr.Min.Y += (b.Dy() - ch) / 2
+			sr.Max.Y = sr.Min.Y + ch
+		}
+	case opts.Width != 0 && opts.Height != 0:
+		// contain: shrink whichever side is relatively larger
+		if b.Dx()*h > b.Dy()*w {
+			h = max(1, b.Dy()*w/b.Dx())
+		} else {
+			w = max(1, b.Dx()*h/b.Dy())
+		}
+	}
+
+	if sr == b && w == b.Dx() && h == b.Dy() {
+		return img
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, sr, draw.Src, nil)
+	return dst
+}
+
+// Encode writes img in the given format, JPEG by default.
+func Encode(w io.Writer, img image.Image, format Format) error {
+	switch format {
+	case PNG:
+		return png.Encode(w, img)
+	case JPEG, "":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func cacheKey(source, version string, opts Options) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s\n%s\n%d\n%d\n%s\n%s", source, version, opts.Width, opts.Height, opts.Fit, opts.Format))
+	return hex.EncodeToString(sum[:])
+}
+
+func etag(key string) string {
+	return `"` + key[:32] + `"`
+}
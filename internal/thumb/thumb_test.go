@@ -0,0 +1,183 @@
+package thumb_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"api/internal/fetch"
+	"api/internal/thumb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newImage returns a 160x90 image whose left, middle and right thirds are red, green and blue.
+func newImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 160, 90))
+	for x := range 160 {
+		c := color.RGBA{R: 255, A: 255}
+		switch {
+		case x >= 107:
+			c = color.RGBA{B: 255, A: 255}
+		case x >= 53:
+			c = color.RGBA{G: 255, A: 255}
+		}
+		for y := range 90 {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestResize(t *testing.T) {
+	img := newImage()
+
+	tests := []struct {
+		name string
+		opts thumb.Options
+		want image.Point
+	}{
+		{"unchanged", thumb.Options{}, image.Pt(160, 90)},
+		{"width", thumb.Options{Width: 80}, image.Pt(80, 45)},
+		{"height", thumb.Options{Height: 45}, image.Pt(80, 45)},
+		{"contain wide", thumb.Options{Width: 40, Height: 40, Fit: thumb.Contain}, image.Pt(40, 22)},
+		{"contain tall", thumb.Options{Width: 320, Height: 45, Fit: thumb.Contain}, image.Pt(80, 45)},
+		{"cover", thumb.Options{Width: 40, Height: 40, Fit: thumb.Cover}, image.Pt(40, 40)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, thumb.Resize(img, tt.opts).Bounds().Size())
+		})
+	}
+}
+
+func TestResize_CoverCropsCenter(t *testing.T) {
+	// a tall crop from the center of the image is all green
+	got := thumb.Resize(newImage(), thumb.Options{Width: 10, Height: 20, Fit: thumb.Cover})
+	for _, p := range []image.Point{{0, 0}, {9, 19}, {0, 19}, {9, 0}} {
+		r, g, b, _ := got.At(p.X, p.Y).RGBA()
+		assert.Zero(t, r>>8, p)
+		assert.Equal(t, uint32(255), g>>8, p)
+		assert.Zero(t, b>>8, p)
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	var src bytes.Buffer
+	require.NoError(t, jpeg.Encode(&src, newImage(), nil))
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write(src.Bytes())
+	}))
+	defer server.Close()
+
+	cache, err := thumb.NewCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+	r := thumb.NewRenderer(&fetch.Fetcher{HC: &http.Client{Timeout: time.Second}}, cache)
+
+	opts := thumb.Options{Width: 80, Format: thumb.PNG}
+	b, tag, err := r.Render(context.Background(), server.URL+"/a.jpg", "v1", opts)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(80, 45), img.Bounds().Size())
+
+	cached, cachedTag, err := r.Render(context.Background(), server.URL+"/a.jpg", "v1", opts)
+	require.NoError(t, err)
+	assert.Equal(t, b, cached)
+	assert.Equal(t, tag, cachedTag)
+	assert.EqualValues(t, 1, calls.Load())
+
+	// a new version of the source is rendered again
+	_, newTag, err := r.Render(context.Background(), server.URL+"/a.jpg", "v2", opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, tag, newTag)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+// key returns a cache key, like the renderer's.
+func key(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRenderer_Render_SourceFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("not an image"))
+	}))
+	defer server.Close()
+
+	r := thumb.NewRenderer(&fetch.Fetcher{HC: &http.Client{Timeout: time.Second}, MaxRetries: -1}, nil)
+	_, _, err := r.Render(context.Background(), server.URL+"/missing.jpg", "v1", thumb.Options{})
+	assert.ErrorIs(t, err, thumb.ErrSource)
+	_, _, err = r.Render(context.Background(), server.URL+"/corrupt.jpg", "v1", thumb.Options{})
+	assert.ErrorIs(t, err, thumb.ErrSource)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := thumb.NewCache(dir, 10)
+	require.NoError(t, err)
+
+	a, b, c := key("a"), key("b"), key("c")
+	require.NoError(t, cache.Put(a, []byte("aaaa")))
+	require.NoError(t, cache.Put(b, []byte("bbbb")))
+	_, ok := cache.Get(a)
+	require.True(t, ok)
+
+	// b is the least recently used, so it makes room for c
+	require.NoError(t, cache.Put(c, []byte("cccc")))
+	assert.EqualValues(t, 8, cache.Size())
+
+	_, ok = cache.Get(b)
+	assert.False(t, ok)
+	_, err = os.Stat(filepath.Join(dir, b))
+	assert.True(t, os.IsNotExist(err))
+
+	got, ok := cache.Get(a)
+	assert.True(t, ok)
+	assert.Equal(t, "aaaa", string(got))
+
+	// files are picked up again, within budget
+	reopened, err := thumb.NewCache(dir, 4)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, reopened.Size())
+}
+
+func TestNewCache_OnlyAdoptsCachedImages(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, key("a")), []byte("aaaa"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tmp-123"), []byte("partial"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("someone else's file"), 0o600))
+
+	cache, err := thumb.NewCache(dir, 1)
+	require.NoError(t, err)
+	assert.Zero(t, cache.Size())
+
+	// the stale write is removed, and the unrelated file is neither counted nor evicted
+	_, err = os.Stat(filepath.Join(dir, ".tmp-123"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "notes.txt"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, key("a")))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,27 @@
+package annotate
+
+import (
+	"context"
+
+	imgpkg "api/internal/updater/image"
+)
+
+// Label is something recognised in an image, with a confidence score between 0 and 1.
+type Label struct {
+	Name  string
+	Score float32
+}
+
+// Annotation is the result of annotating an image.
+type Annotation struct {
+	Labels      []Label
+	Colors      []imgpkg.RGB // Dominant colors, most dominant first
+	Landmarks   []imgpkg.Landmark
+	WebEntities []imgpkg.WebEntity
+	SafeSearch  *imgpkg.SafeSearch // Nil if not detected
+}
+
+// Annotator labels an encoded image and finds its dominant colors.
+type Annotator interface {
+	Annotate(ctx context.Context, img []byte) (*Annotation, error)
+}
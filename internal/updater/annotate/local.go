@@ -0,0 +1,230 @@
+package annotate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register decoder for Bing images
+	_ "image/png"  // register decoder for tests and mirrors
+	"math"
+	"os"
+	"slices"
+
+	imgpkg "api/internal/updater/image"
+)
+
+const (
+	defaultK   = 4
+	maxSamples = 16384
+	iterations = 20
+)
+
+// Local annotates images offline.
+// Dominant colors are found with k-means over the decoded pixels, and labels come from optional color rules.
+type Local struct {
+	K     int // Number of dominant colors, defaults to 4
+	Rules []Rule
+}
+
+// Rule labels an image when enough of the pixels in a region are close to a color.
+// The label's score is the share of matching pixels.
+type Rule struct {
+	Label    string  `json:"label"`
+	Color    string  `json:"color"`            // Hex string like "#87CEEB"
+	Distance float64 `json:"distance"`         // Maximum RGB distance from Color
+	MinShare float64 `json:"minShare"`         // Share of the region's pixels that must match, between 0 and 1
+	Region   string  `json:"region,omitempty"` // "top", "bottom" or empty for the whole image
+}
+
+// LoadRules reads rules from a JSON file containing an array of rules.
+func LoadRules(path string) ([]Rule, error) {
+	b, err := os.ReadFile(path) // #nosec G304 -- path comes from configuration
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules %s: %w", path, err)
+	}
+
+	for _, r := range rules {
+		if _, err := imgpkg.ParseHex(r.Color); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", r.Label, err)
+		}
+	}
+	return rules, nil
+}
+
+type pixel struct {
+	c [3]float64
+	y float64 // Vertical position between 0 (top) and 1 (bottom)
+}
+
+func (l *Local) Annotate(_ context.Context, b []byte) (*Annotation, error) {
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	pixels := samplePixels(img)
+	if len(pixels) == 0 {
+		return nil, fmt.Errorf("image has no pixels")
+	}
+
+	k := l.K
+	if k <= 0 {
+		k = defaultK
+	}
+
+	anno := &Annotation{Colors: kmeans(pixels, k)}
+
+	for _, r := range l.Rules {
+		if share := r.share(pixels); share >= r.MinShare {
+			anno.Labels = append(anno.Labels, Label{Name: r.Label, Score: float32(share)})
+		}
+	}
+	slices.SortStableFunc(anno.Labels, func(a, b Label) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return anno, nil
+}
+
+// share returns the fraction of pixels in the rule's region that are close to its color.
+func (r Rule) share(pixels []pixel) float64 {
+	target, err := imgpkg.ParseHex(r.Color)
+	if err != nil {
+		return 0
+	}
+	t := [3]float64{float64(target[0]), float64(target[1]), float64(target[2])}
+
+	var total, matched int
+	for _, p := range pixels {
+		if (r.Region == "top" && p.y >= 0.5) || (r.Region == "bottom" && p.y < 0.5) {
+			continue
+		}
+		total++
+		if distance(p.c, t) <= r.Distance {
+			matched++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(matched) / float64(total)
+}
+
+// samplePixels returns about maxSamples pixels spread evenly over the image.
+func samplePixels(img image.Image) []pixel {
+	b := img.Bounds()
+	step := max(1, int(math.Sqrt(float64(b.Dx()*b.Dy())/maxSamples)))
+
+	pixels := make([]pixel, 0, maxSamples)
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			pixels = append(pixels, pixel{
+				c: [3]float64{float64(r >> 8), float64(g >> 8), float64(bl >> 8)},
+				y: float64(y-b.Min.Y) / float64(b.Dy()),
+			})
+		}
+	}
+	return pixels
+}
+
+// kmeans clusters the pixel colors and returns the centroids, largest cluster first.
+// Centroids start at luma quantiles so results are deterministic.
+func kmeans(pixels []pixel, k int) []imgpkg.RGB {
+	sorted := slices.Clone(pixels)
+	slices.SortFunc(sorted, func(a, b pixel) int {
+		la, lb := luma(a.c), luma(b.c)
+		switch {
+		case la < lb:
+			return -1
+		case la > lb:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	k = min(k, len(sorted))
+	centroids := make([][3]float64, k)
+	for i := range centroids {
+		centroids[i] = sorted[(2*i+1)*len(sorted)/(2*k)].c
+	}
+
+	assign := make([]int, len(pixels))
+	counts := make([]int, k)
+	for range iterations {
+		changed := false
+		for i, p := range pixels {
+			best, bestDist := 0, math.MaxFloat64
+			for j, c := range centroids {
+				if d := distance(p.c, c); d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+			if assign[i] != best {
+				assign[i] = best
+				changed = true
+			}
+		}
+
+		sums := make([][3]float64, k)
+		clear(counts)
+		for i, p := range pixels {
+			j := assign[i]
+			for ch := range 3 {
+				sums[j][ch] += p.c[ch]
+			}
+			counts[j]++
+		}
+		for j := range centroids {
+			if counts[j] == 0 {
+				continue
+			}
+			for ch := range 3 {
+				centroids[j][ch] = sums[j][ch] / float64(counts[j])
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	order := make([]int, 0, k)
+	for j := range centroids {
+		if counts[j] > 0 {
+			order = append(order, j)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return counts[b] - counts[a] })
+
+	colors := make([]imgpkg.RGB, len(order))
+	for i, j := range order {
+		c := centroids[j]
+		colors[i] = imgpkg.RGB{int(math.Round(c[0])), int(math.Round(c[1])), int(math.Round(c[2]))}
+	}
+	return colors
+}
+
+func luma(c [3]float64) float64 {
+	return 0.299*c[0] + 0.587*c[1] + 0.114*c[2]
+}
+
+func distance(a, b [3]float64) float64 {
+	dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
@@ -0,0 +1,55 @@
+package annotate_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"api/internal/updater/annotate"
+	imgpkg "api/internal/updater/image"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// landscape encodes an image with a sky blue top two thirds and a green bottom third.
+func landscape(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for y := range 200 {
+		for x := range 300 {
+			c := color.RGBA{R: 135, G: 206, B: 235, A: 255}
+			if y >= 133 {
+				c = color.RGBA{R: 34, G: 139, B: 34, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestLocal_Annotate(t *testing.T) {
+	l := annotate.Local{
+		K: 2,
+		Rules: []annotate.Rule{
+			{Label: "sky", Color: "#87CEEB", Distance: 40, MinShare: 0.5, Region: "top"},
+			{Label: "grass", Color: "#228B22", Distance: 40, MinShare: 0.2},
+			{Label: "sand", Color: "#C2B280", Distance: 40, MinShare: 0.1},
+		},
+	}
+
+	anno, err := l.Annotate(context.Background(), landscape(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []imgpkg.RGB{{135, 206, 235}, {34, 139, 34}}, anno.Colors)
+	require.Len(t, anno.Labels, 2)
+	assert.Equal(t, "sky", anno.Labels[0].Name)
+	assert.InDelta(t, 1, anno.Labels[0].Score, 0.01)
+	assert.Equal(t, "grass", anno.Labels[1].Name)
+	assert.InDelta(t, 0.335, anno.Labels[1].Score, 0.01)
+}
@@ -0,0 +1,110 @@
+package annotate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	imgpkg "api/internal/updater/image"
+
+	vision "cloud.google.com/go/vision/v2/apiv1"
+	"cloud.google.com/go/vision/v2/apiv1/visionpb"
+)
+
+// Vision annotates images with the Google Cloud Vision API.
+type Vision struct {
+	Client *vision.ImageAnnotatorClient
+}
+
+func NewVision(ctx context.Context) (*Vision, error) {
+	client, err := vision.NewImageAnnotatorClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Vision{Client: client}, nil
+}
+
+func (v *Vision) Annotate(ctx context.Context, img []byte) (*Annotation, error) {
+	req := &visionpb.BatchAnnotateImagesRequest{
+		Requests: []*visionpb.AnnotateImageRequest{
+			{
+				Image: &visionpb.Image{
+					Content: img,
+				},
+				Features: []*visionpb.Feature{
+					{
+						Type:       visionpb.Feature_LABEL_DETECTION,
+						MaxResults: 50,
+					},
+					{
+						Type:       visionpb.Feature_IMAGE_PROPERTIES,
+						MaxResults: 4,
+					},
+					{
+						Type:       visionpb.Feature_LANDMARK_DETECTION,
+						MaxResults: 5,
+					},
+					{
+						Type:       visionpb.Feature_WEB_DETECTION,
+						MaxResults: 10,
+					},
+					{
+						Type: visionpb.Feature_SAFE_SEARCH_DETECTION,
+					},
+				},
+			},
+		},
+	}
+	images, err := v.Client.BatchAnnotateImages(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("vision API call failed: %w", err)
+	}
+
+	responses := images.GetResponses()
+	if len(responses) == 0 {
+		return nil, errors.New("vision API returned no responses")
+	}
+
+	resp := responses[0]
+	if resp.GetError() != nil {
+		return nil, fmt.Errorf("vision API error: %s", resp.GetError().GetMessage())
+	}
+
+	anno := &Annotation{}
+	for _, l := range resp.GetLabelAnnotations() {
+		anno.Labels = append(anno.Labels, Label{Name: l.GetDescription(), Score: l.GetScore()})
+	}
+
+	for _, c := range resp.GetImagePropertiesAnnotation().GetDominantColors().GetColors() {
+		rgb := c.GetColor()
+		anno.Colors = append(anno.Colors, imgpkg.RGB{int(rgb.GetRed()), int(rgb.GetGreen()), int(rgb.GetBlue())})
+	}
+
+	for _, l := range resp.GetLandmarkAnnotations() {
+		landmark := imgpkg.Landmark{Name: l.GetDescription(), Score: l.GetScore()}
+		if locs := l.GetLocations(); len(locs) > 0 {
+			landmark.Lat = locs[0].GetLatLng().GetLatitude()
+			landmark.Lng = locs[0].GetLatLng().GetLongitude()
+		}
+		anno.Landmarks = append(anno.Landmarks, landmark)
+	}
+
+	for _, e := range resp.GetWebDetection().GetWebEntities() {
+		if e.GetDescription() == "" {
+			continue
+		}
+		anno.WebEntities = append(anno.WebEntities, imgpkg.WebEntity{Description: e.GetDescription(), Score: e.GetScore()})
+	}
+
+	if ss := resp.GetSafeSearchAnnotation(); ss != nil {
+		anno.SafeSearch = &imgpkg.SafeSearch{
+			Adult:    ss.GetAdult().String(),
+			Spoof:    ss.GetSpoof().String(),
+			Medical:  ss.GetMedical().String(),
+			Violence: ss.GetViolence().String(),
+			Racy:     ss.GetRacy().String(),
+		}
+	}
+
+	return anno, nil
+}
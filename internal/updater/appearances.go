@@ -0,0 +1,59 @@
+package updater
+
+import (
+	"slices"
+
+	imgpkg "api/internal/updater/image"
+)
+
+// history returns the appearances of a stored image.
+// Documents stored before appearances were recorded are seeded with their own market and date.
+func history(image imgpkg.Image) []imgpkg.Appearance {
+	if len(image.Appearances) > 0 || image.Market == "" {
+		return image.Appearances
+	}
+	return []imgpkg.Appearance{{Market: image.Market, Date: image.Date}}
+}
+
+// mergeAppearances adds the appearances in b that are not in a, sorted by date then market.
+// An appearance is identified by its market and date; localized text missing from a is filled in from b.
+func mergeAppearances(a, b []imgpkg.Appearance) []imgpkg.Appearance {
+	out := slices.Clone(a)
+	for _, v := range b {
+		i := slices.IndexFunc(out, func(o imgpkg.Appearance) bool {
+			return o.Market == v.Market && o.Date == v.Date
+		})
+		if i < 0 {
+			out = append(out, v)
+			continue
+		}
+		if out[i].Title == "" && out[i].Copyright == "" {
+			out[i].Title, out[i].Copyright = v.Title, v.Copyright
+		}
+	}
+
+	slices.SortStableFunc(out, func(x, y imgpkg.Appearance) int {
+		if x.Date != y.Date {
+			return x.Date - y.Date
+		}
+		if x.Market < y.Market {
+			return -1
+		}
+		if x.Market > y.Market {
+			return 1
+		}
+		return 0
+	})
+	return out
+}
+
+// appearanceMarkets returns the distinct markets of the appearances, in order.
+func appearanceMarkets(appearances []imgpkg.Appearance) []string {
+	var markets []string
+	for _, a := range appearances {
+		if !slices.Contains(markets, a.Market) {
+			markets = append(markets, a.Market)
+		}
+	}
+	return markets
+}
@@ -0,0 +1,68 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"api/internal/collections"
+	"api/internal/updater/firestore"
+)
+
+// RebuildAttributions parses the photographer and agency of every stored image again, saving those that changed,
+// and recounts the photographers collection. It returns the IDs of the images that changed.
+func (u *Updater) RebuildAttributions(ctx context.Context) ([]string, error) {
+	photographers := make(map[string]*firestore.Photographer)
+	updated := []string{}
+
+	q := firestore.ListQuery{Limit: reannotatePageSize}
+	for {
+		images, err := u.firestoreClient.List(ctx, q)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, image := range images {
+			parsed := image
+			parsed.SetAttribution()
+			if parsed.Photographer != image.Photographer || parsed.Agency != image.Agency {
+				if err := u.firestoreClient.UpdateAttribution(ctx, parsed); err != nil {
+					return updated, err
+				}
+				updated = append(updated, image.ID)
+			}
+
+			if parsed.PhotographerSlug == "" {
+				continue
+			}
+			p, ok := photographers[parsed.PhotographerSlug]
+			if !ok {
+				p = &firestore.Photographer{Slug: parsed.PhotographerSlug}
+				photographers[parsed.PhotographerSlug] = p
+			}
+			// images are in date order, so the latest name and agency win
+			p.Name, p.Count = parsed.Photographer, p.Count+1
+			if parsed.Agency != "" {
+				p.Agency = parsed.Agency
+			}
+		}
+
+		if len(images) < q.Limit {
+			break
+		}
+		last := images[len(images)-1]
+		q.StartAfterDate, q.StartAfterID = last.Date, last.ID
+	}
+
+	list := make([]firestore.Photographer, 0, len(photographers))
+	for _, slug := range slices.Sorted(maps.Keys(photographers)) {
+		list = append(list, *photographers[slug])
+	}
+	if err := u.firestoreClient.SetPhotographers(ctx, collections.Photographers, list); err != nil {
+		return updated, err
+	}
+
+	fmt.Printf("%d photographers, %d images updated\n", len(list), len(updated))
+	return updated, nil
+}
@@ -0,0 +1,80 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	imgpkg "api/internal/updater/image"
+	"api/internal/updater/message"
+)
+
+const (
+	// archivePage is the number of days listed per page of Bing's archive
+	archivePage = 8
+	// archiveMaxDaysBack is the furthest back a page of Bing's archive can start
+	archiveMaxDaysBack = 7
+)
+
+// Backfill fetches the wallpapers of markets, or all markets if empty, dated from to to (inclusive, e.g. 20250101),
+// and stores what changed. Bing's archive only goes back about two weeks, so older dates are skipped.
+func (u *Updater) Backfill(ctx context.Context, from, to int, markets []string, res *Result) error {
+	newest, err := daysBack(to)
+	if err != nil {
+		return err
+	}
+	oldest, err := daysBack(from)
+	if err != nil {
+		return err
+	}
+	if newest > archiveMaxDaysBack+archivePage-1 {
+		return fmt.Errorf("%w: %d is older than Bing's archive", message.ErrInvalid, to)
+	}
+	if oldest > archiveMaxDaysBack+archivePage-1 {
+		log.Printf("warning: only backfilling the last %d days, Bing's archive doesn't go back to %d", archiveMaxDaysBack+archivePage, from)
+	}
+
+	fmt.Printf("backfilling images from %d to %d\n", from, to)
+
+	if len(markets) == 0 {
+		markets = append(ENMarkets, nonENMarkets...)
+	}
+
+	images := make(map[string]imgpkg.Image)
+	for _, market := range markets {
+		for idx := min(max(newest, 0), archiveMaxDaysBack); ; idx = min(idx+archivePage, archiveMaxDaysBack) {
+			bi, err := u.imageClient.ListArchive(ctx, market, idx)
+			if err != nil {
+				return err
+			}
+			n, err := dedupeImages(bi, market, images)
+			if err != nil {
+				return err
+			}
+			res.Markets[market] += n
+
+			if idx+archivePage > oldest || idx == archiveMaxDaysBack {
+				break
+			}
+		}
+	}
+
+	for id, image := range images {
+		if image.Date < from || image.Date > to {
+			delete(images, id)
+		}
+	}
+
+	return u.apply(ctx, images, res)
+}
+
+// daysBack returns how many days ago a date like 20250101 was, in UTC.
+func daysBack(date int) (int, error) {
+	t, err := time.Parse("20060102", fmt.Sprint(date))
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid date %d", message.ErrInvalid, date)
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return int(today.Sub(t).Hours() / 24), nil
+}
@@ -1,12 +1,32 @@
 package bing
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"log"
-	"net/http"
+	"regexp"
+	"strings"
+
+	"api/internal/fetch"
 )
 
+// Endpoints are the supported endpoints by name. Each is formatted with the market.
+var Endpoints = map[string]string{
+	"archive": "/HPImageArchive.aspx?format=js&n=8&mbl=1&mkt=%s",
+	"xml":     "/HPImageArchive.aspx?format=xml&n=8&mbl=1&mkt=%s",
+	"model":   "/hp/api/model?mkt=%s",
+}
+
+// archiveAt pages back through the archive, formatted with the number of days back and the market.
+const archiveAt = "/HPImageArchive.aspx?format=js&idx=%d&n=8&mbl=1&mkt=%s"
+
+// DefaultEndpoints are tried in order until one returns images.
+var DefaultEndpoints = []string{Endpoints["archive"], Endpoints["xml"], Endpoints["model"]}
+
 type ListResponse struct {
 	Market struct {
 		Market string `json:"mkt"`
@@ -15,27 +35,116 @@ type ListResponse struct {
 }
 
 type Image struct {
-	Copyright string `json:"copyright"`
-	StartDate string `json:"startdate"`
-	URL       string `json:"url"`
-	URLBase   string `json:"urlbase"`
-	WP        bool   `json:"wp"`
+	Copyright     string    `json:"copyright"`
+	StartDate     string    `json:"startdate"`
+	URL           string    `json:"url"`
+	URLBase       string    `json:"urlbase"`
+	WP            bool      `json:"wp"`
+	Title         string    `json:"title"`         // Headline, e.g. "Happy Presidents Day!"
+	CopyrightLink string    `json:"copyrightlink"` // Bing search about the image
+	Quiz          string    `json:"quiz"`
+	Hsh           string    `json:"hsh"` // Hash of the image content
+	FullStartDate string    `json:"fullstartdate"`
+	EndDate       string    `json:"enddate"`
+	Hotspots      []Hotspot `json:"hs"`
+}
+
+// Hotspot is a point of interest on the homepage image.
+type Hotspot struct {
+	Desc  string `json:"desc"`
+	Link  string `json:"link"`
+	Query string `json:"query"`
+	LocX  int    `json:"locx"`
+	LocY  int    `json:"locy"`
 }
 
 type Client struct {
 	BaseURL string
-	HC      *http.Client
+	Fetcher *fetch.Fetcher
+	// Endpoints overrides DefaultEndpoints.
+	Endpoints []string
 }
 
-func (c *Client) List(_ context.Context, market string) ([]Image, error) {
-	resp, err := c.HC.Get(c.BaseURL + "/HPImageArchive.aspx?format=js&n=8&mbl=1&mkt=" + market)
+// List returns the latest images of a market from the first endpoint that returns any.
+// Responses are decoded by their shape rather than the endpoint they came from.
+func (c *Client) List(ctx context.Context, market string) ([]Image, error) {
+	endpoints := c.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = DefaultEndpoints
+	}
+
+	var errs []error
+	for _, endpoint := range endpoints {
+		url := c.BaseURL + fmt.Sprintf(endpoint, market)
+
+		images, err := c.list(ctx, url, market)
+		if err == nil && len(images) == 0 {
+			err = errors.New("no images")
+		}
+		if err != nil {
+			log.Printf("warning: %s: %v", url, err)
+			errs = append(errs, fmt.Errorf("%s: %w", url, err))
+			continue
+		}
+		return images, nil
+	}
+
+	return nil, fmt.Errorf("failed to list images for %s: %w", market, errors.Join(errs...))
+}
+
+// ListArchive returns the 8 images of a market starting daysBack days ago. Bing keeps about two weeks of images.
+func (c *Client) ListArchive(ctx context.Context, market string, daysBack int) ([]Image, error) {
+	url := c.BaseURL + fmt.Sprintf(archiveAt, daysBack, market)
+
+	images, err := c.list(ctx, url, market)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images for %s %d days back: %w", market, daysBack, err)
+	}
+	return images, nil
+}
+
+func (c *Client) list(ctx context.Context, url, market string) ([]Image, error) {
+	body, err := c.Fetcher.Get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
+	return Decode(body, market)
+}
+
+// Decode detects which of the supported response shapes body has and returns its images.
+func Decode(body []byte, market string) ([]Image, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, errors.New("empty response")
+	}
+
+	if body[0] == '<' {
+		return decodeXML(body)
+	}
+
+	var shape struct {
+		Images        json.RawMessage `json:"images"`
+		MediaContents json.RawMessage `json:"MediaContents"`
+	}
+	if err := json.Unmarshal(body, &shape); err != nil {
+		return nil, fmt.Errorf("unrecognised response: %w", err)
+	}
+
+	switch {
+	case shape.Images != nil:
+		return decodeArchive(body, market)
+	case shape.MediaContents != nil:
+		return decodeModel(body)
+	default:
+		return nil, errors.New("unrecognised response shape")
+	}
+}
+
+// decodeArchive decodes the HPImageArchive JSON format.
+func decodeArchive(body []byte, market string) ([]Image, error) {
 	lr := new(ListResponse)
-	if err := json.NewDecoder(resp.Body).Decode(lr); err != nil {
+	if err := json.Unmarshal(body, lr); err != nil {
 		return nil, err
 	}
 
@@ -45,3 +154,93 @@ func (c *Client) List(_ context.Context, market string) ([]Image, error) {
 
 	return lr.Images, nil
 }
+
+type xmlResponse struct {
+	XMLName xml.Name `xml:"images"`
+	Images  []struct {
+		StartDate     string `xml:"startdate"`
+		FullStartDate string `xml:"fullstartdate"`
+		EndDate       string `xml:"enddate"`
+		URL           string `xml:"url"`
+		URLBase       string `xml:"urlBase"`
+		Copyright     string `xml:"copyright"`
+		CopyrightLink string `xml:"copyrightlink"`
+		Headline      string `xml:"headline"`
+		WP            bool   `xml:"wp"`
+	} `xml:"image"`
+}
+
+// decodeXML decodes the HPImageArchive XML format, which has no hash or quiz.
+func decodeXML(body []byte) ([]Image, error) {
+	var xr xmlResponse
+	if err := xml.Unmarshal(body, &xr); err != nil {
+		return nil, err
+	}
+
+	images := make([]Image, 0, len(xr.Images))
+	for _, v := range xr.Images {
+		images = append(images, Image{
+			Copyright:     v.Copyright,
+			StartDate:     v.StartDate,
+			URL:           v.URL,
+			URLBase:       v.URLBase,
+			WP:            v.WP,
+			Title:         v.Headline,
+			CopyrightLink: v.CopyrightLink,
+			FullStartDate: v.FullStartDate,
+			EndDate:       v.EndDate,
+		})
+	}
+	return images, nil
+}
+
+type modelResponse struct {
+	MediaContents []struct {
+		ImageContent struct {
+			Title     string
+			Headline  string
+			Copyright string
+			Image     struct {
+				URL          string `json:"Url"`
+				Downloadable bool
+			}
+			TriviaURL    string `json:"TriviaUrl"`
+			BackstageURL string `json:"BackstageUrl"`
+		}
+		Ssd string // Start date and time, e.g. "20230220_0800"
+	}
+}
+
+// resolutionSuffix matches the resolution and query string ending an image URL, e.g. "_1920x1080.jpg&rf=...".
+var resolutionSuffix = regexp.MustCompile(`_(\d+x\d+|UHD)\.jpg.*$`)
+
+// decodeModel decodes the JSON model behind the Bing homepage, which splits the title and copyright.
+func decodeModel(body []byte) ([]Image, error) {
+	var mr modelResponse
+	if err := json.Unmarshal(body, &mr); err != nil {
+		return nil, err
+	}
+
+	images := make([]Image, 0, len(mr.MediaContents))
+	for _, v := range mr.MediaContents {
+		ic := v.ImageContent
+		copyright := ic.Copyright
+		if !strings.HasPrefix(copyright, "©") {
+			copyright = "© " + copyright
+		}
+
+		date, clock, _ := strings.Cut(v.Ssd, "_")
+		images = append(images, Image{
+			Copyright:     fmt.Sprintf("%s (%s)", ic.Title, copyright),
+			StartDate:     date,
+			URL:           ic.Image.URL,
+			URLBase:       resolutionSuffix.ReplaceAllString(ic.Image.URL, ""),
+			WP:            ic.Image.Downloadable,
+			Title:         ic.Headline,
+			CopyrightLink: ic.BackstageURL,
+			Quiz:          ic.TriviaURL,
+			FullStartDate: date + clock,
+		})
+	}
+	return images, nil
+}
@@ -4,92 +4,184 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"api/internal/fetch"
 	"api/internal/updater/bing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-const bingResponse = `{
-  "market":{
-    "mkt":"en-US"
-  },
-  "images":[
-    {
-      "startdate":"20230220",
-      "fullstartdate":"202302200800",
-      "enddate":"20230221",
-      "url":"/th?id=OHR.PresDayDC_EN-US2054662773_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
-      "urlbase":"/th?id=OHR.PresDayDC_EN-US2054662773",
-      "copyright":"Washington Monument and Capitol Building on the National Mall, Washington, DC (© AevanStock/Shutterstock)",
-      "copyrightlink":"https://www.bing.com/search?q=presidents+day&form=hpcapt&filters=HpDate%3a%2220230220_0800%22",
-      "title":"Happy Presidents Day!",
-      "quiz":"/search?q=Bing+homepage+quiz&filters=WQOskey:%22HPQuiz_20230220_PresDayDC%22&FORM=HPQUIZ",
-      "wp":true,
-      "hsh":"5a45c7b3845a8d20ceccf5b78daa78db",
-      "drk":1,
-      "top":1,
-      "bot":1,
-      "hs":[]
-    },
-    {
-      "startdate":"20230219",
-      "fullstartdate":"202302190800",
-      "enddate":"20230220",
-      "url":"/th?id=OHR.MauiWhale_EN-US1928366389_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
-      "urlbase":"/th?id=OHR.MauiWhale_EN-US1928366389",
-      "copyright":"Humpback whales, Maui, Hawaii (© Flip Nicklin/Minden Pictures)",
-      "copyrightlink":"https://www.bing.com/search?q=humpback+whale&form=hpcapt&filters=HpDate%3a%2220230219_0800%22",
-      "title":"Migrating giants",
-      "quiz":"/search?q=Bing+homepage+quiz&filters=WQOskey:%22HPQuiz_20230219_MauiWhale%22&FORM=HPQUIZ",
-      "wp":true,
-      "hsh":"75e73590a63a5d3bc6831f06d68b7c34",
-      "drk":1,
-      "top":1,
-      "bot":1,
-      "hs":[]
-    }
-  ],
-  "tooltips":{
-    "loading":"Loading...",
-    "previous":"Previous image",
-    "next":"Next image",
-    "walle":"This image is not available to download as wallpaper.",
-    "walls":"Download this image. Use of this image is restricted to wallpaper only."
-  }
-}`
+// fixtures maps each endpoint's path to a response recorded from it.
+var fixtures = map[string]string{
+	"/HPImageArchive.aspx?format=js":  "testdata/archive.json",
+	"/HPImageArchive.aspx?format=xml": "testdata/archive.xml",
+	"/hp/api/model":                   "testdata/model.json",
+}
 
-func TestClient_List(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, err := w.Write([]byte(bingResponse))
-		require.Nil(t, err)
+// newServer serves the fixtures, failing the endpoints in broken with a 500.
+func newServer(t *testing.T, broken ...string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for prefix, path := range fixtures {
+			if !strings.HasPrefix(r.URL.RequestURI(), prefix) {
+				continue
+			}
+			if slices.Contains(broken, prefix) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			body, err := os.ReadFile(path)
+			require.NoError(t, err)
+			_, err = w.Write(body)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
 	}))
-	defer server.Close()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newFetcher() *fetch.Fetcher {
+	return &fetch.Fetcher{HC: &http.Client{Timeout: time.Second}, MaxRetries: -1}
+}
+
+func TestClient_List(t *testing.T) {
+	server := newServer(t)
 
-	hc := &http.Client{Timeout: time.Second}
-	c := bing.Client{BaseURL: server.URL, HC: hc}
+	c := bing.Client{BaseURL: server.URL, Fetcher: newFetcher()}
 	images, err := c.List(context.Background(), "en-US")
 	assert.NoError(t, err)
 
 	want := []bing.Image{
 		{
-			Copyright: "Washington Monument and Capitol Building on the National Mall, Washington, DC (© AevanStock/Shutterstock)",
-			StartDate: "20230220",
-			URL:       "/th?id=OHR.PresDayDC_EN-US2054662773_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
-			URLBase:   "/th?id=OHR.PresDayDC_EN-US2054662773",
-			WP:        true,
+			Copyright:     "Washington Monument and Capitol Building on the National Mall, Washington, DC (© AevanStock/Shutterstock)",
+			StartDate:     "20230220",
+			URL:           "/th?id=OHR.PresDayDC_EN-US2054662773_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
+			URLBase:       "/th?id=OHR.PresDayDC_EN-US2054662773",
+			WP:            true,
+			Title:         "Happy Presidents Day!",
+			CopyrightLink: "https://www.bing.com/search?q=presidents+day&form=hpcapt&filters=HpDate%3a%2220230220_0800%22",
+			Quiz:          "/search?q=Bing+homepage+quiz&filters=WQOskey:%22HPQuiz_20230220_PresDayDC%22&FORM=HPQUIZ",
+			Hsh:           "5a45c7b3845a8d20ceccf5b78daa78db",
+			FullStartDate: "202302200800",
+			EndDate:       "20230221",
+			Hotspots:      []bing.Hotspot{},
 		},
 		{
-			Copyright: "Humpback whales, Maui, Hawaii (© Flip Nicklin/Minden Pictures)",
-			StartDate: "20230219",
-			URL:       "/th?id=OHR.MauiWhale_EN-US1928366389_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
-			URLBase:   "/th?id=OHR.MauiWhale_EN-US1928366389",
-			WP:        true,
+			Copyright:     "Humpback whales, Maui, Hawaii (© Flip Nicklin/Minden Pictures)",
+			StartDate:     "20230219",
+			URL:           "/th?id=OHR.MauiWhale_EN-US1928366389_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
+			URLBase:       "/th?id=OHR.MauiWhale_EN-US1928366389",
+			WP:            true,
+			Title:         "Migrating giants",
+			CopyrightLink: "https://www.bing.com/search?q=humpback+whale&form=hpcapt&filters=HpDate%3a%2220230219_0800%22",
+			Quiz:          "/search?q=Bing+homepage+quiz&filters=WQOskey:%22HPQuiz_20230219_MauiWhale%22&FORM=HPQUIZ",
+			Hsh:           "75e73590a63a5d3bc6831f06d68b7c34",
+			FullStartDate: "202302190800",
+			EndDate:       "20230220",
+			Hotspots:      []bing.Hotspot{},
 		},
 	}
 
 	assert.Equal(t, images, want)
 }
+
+func TestClient_ListArchive(t *testing.T) {
+	body, err := os.ReadFile("testdata/archive.json")
+	require.NoError(t, err)
+
+	var uri string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	c := bing.Client{BaseURL: server.URL, Fetcher: newFetcher()}
+	images, err := c.ListArchive(context.Background(), "ja-JP", 7)
+	require.NoError(t, err)
+	assert.Len(t, images, 2)
+	assert.Equal(t, "/HPImageArchive.aspx?format=js&idx=7&n=8&mbl=1&mkt=ja-JP", uri)
+}
+
+func TestClient_List_FallsBackToXML(t *testing.T) {
+	server := newServer(t, "/HPImageArchive.aspx?format=js")
+
+	c := bing.Client{BaseURL: server.URL, Fetcher: newFetcher()}
+	images, err := c.List(context.Background(), "en-US")
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+
+	assert.Equal(t, bing.Image{
+		Copyright:     "Washington Monument and Capitol Building on the National Mall, Washington, DC (© AevanStock/Shutterstock)",
+		StartDate:     "20230220",
+		URL:           "/th?id=OHR.PresDayDC_EN-US2054662773_1920x1080.jpg&rf=LaDigue_1920x1080.jpg&pid=hp",
+		URLBase:       "/th?id=OHR.PresDayDC_EN-US2054662773",
+		WP:            true,
+		Title:         "Happy Presidents Day!",
+		CopyrightLink: "https://www.bing.com/search?q=presidents+day&form=hpcapt&filters=HpDate%3a%2220230220_0800%22",
+		FullStartDate: "202302200800",
+		EndDate:       "20230221",
+	}, images[0])
+	// not a wallpaper, so the updater skips it
+	assert.False(t, images[1].WP)
+}
+
+func TestClient_List_FallsBackToModel(t *testing.T) {
+	server := newServer(t, "/HPImageArchive.aspx?format=js", "/HPImageArchive.aspx?format=xml")
+
+	c := bing.Client{BaseURL: server.URL, Fetcher: newFetcher()}
+	images, err := c.List(context.Background(), "en-US")
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+
+	assert.Equal(t, bing.Image{
+		Copyright:     "Washington Monument and Capitol Building on the National Mall, Washington, DC (© AevanStock/Shutterstock)",
+		StartDate:     "20230220",
+		URL:           "/th?id=OHR.PresDayDC_EN-US2054662773_1920x1080.jpg&rf=LaDigue_1920x1080.jpg",
+		URLBase:       "/th?id=OHR.PresDayDC_EN-US2054662773",
+		WP:            true,
+		Title:         "Happy Presidents Day!",
+		CopyrightLink: "/search?q=presidents+day&form=hpcapt&filters=HpDate%3a%2220230220_0800%22",
+		Quiz:          "/search?q=Bing+homepage+quiz&filters=WQOskey:%22HPQuiz_20230220_PresDayDC%22&FORM=HPQUIZ",
+		FullStartDate: "202302200800",
+	}, images[0])
+	assert.False(t, images[1].WP)
+}
+
+func TestClient_List_AllFail(t *testing.T) {
+	server := newServer(t, "/HPImageArchive.aspx?format=js", "/HPImageArchive.aspx?format=xml", "/hp/api/model")
+
+	c := bing.Client{BaseURL: server.URL, Fetcher: newFetcher()}
+	_, err := c.List(context.Background(), "en-US")
+	assert.ErrorContains(t, err, "failed to list images for en-US")
+}
+
+func TestDecode_DetectsShape(t *testing.T) {
+	// an endpoint returning another endpoint's shape is still decoded
+	for _, path := range fixtures {
+		body, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		images, err := bing.Decode(body, "en-US")
+		require.NoError(t, err, path)
+		require.Len(t, images, 2, path)
+		assert.Equal(t, "/th?id=OHR.MauiWhale_EN-US1928366389", images[1].URLBase, path)
+		assert.Equal(t, "20230219", images[1].StartDate, path)
+	}
+
+	_, err := bing.Decode([]byte(`{"unexpected": []}`), "en-US")
+	assert.Error(t, err)
+
+	_, err = bing.Decode([]byte(`<html><body>Service unavailable</body></html>`), "en-US")
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package blob
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501 -- GCS verifies uploads with MD5, not used for security
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+// cacheControl lets clients cache mirrored images for a day, since a replaced image keeps its key.
+const cacheControl = "public, max-age=86400"
+
+// Store saves blobs under a key, such as "PresDayDC/UHD.jpg", and serves them from a URL.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// URL returns where the blob is served from.
+	URL(key string) string
+}
+
+// FS stores blobs as files under Dir, which is expected to be served at BaseURL.
+type FS struct {
+	Dir     string
+	BaseURL string
+}
+
+func (f *FS) Put(_ context.Context, key string, data []byte, _ string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so a partly written blob is never served
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *FS) URL(key string) string {
+	return joinURL(f.BaseURL, key)
+}
+
+// path returns the file of a key, rejecting keys outside Dir.
+func (f *FS) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(f.Dir, filepath.FromSlash(key)), nil
+}
+
+// GCS stores blobs in a Cloud Storage bucket.
+type GCS struct {
+	Bucket  *storage.BucketHandle
+	BaseURL string // defaults to the bucket's public URL
+	name    string
+}
+
+func NewGCS(client *storage.Client, bucket, baseURL string) *GCS {
+	if baseURL == "" {
+		baseURL = "https://storage.googleapis.com/" + bucket
+	}
+	return &GCS{Bucket: client.Bucket(bucket), BaseURL: baseURL, name: bucket}
+}
+
+func (g *GCS) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	sum := md5.Sum(data) // #nosec G401
+
+	w := g.Bucket.Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	w.CacheControl = cacheControl
+	w.MD5 = sum[:]
+
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("failed to write gs://%s/%s: %w", g.name, key, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write gs://%s/%s: %w", g.name, key, err)
+	}
+	return nil
+}
+
+func (g *GCS) URL(key string) string {
+	return joinURL(g.BaseURL, key)
+}
+
+func joinURL(base, key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.Join(segments, "/")
+}
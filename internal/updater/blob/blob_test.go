@@ -0,0 +1,38 @@
+package blob_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"api/internal/updater/blob"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS_Put(t *testing.T) {
+	dir := t.TempDir()
+	fs := &blob.FS{Dir: dir, BaseURL: "https://img.example.com/mirror/"}
+
+	require.NoError(t, fs.Put(context.Background(), "PresDayDC/UHD.jpg", []byte("first"), "image/jpeg"))
+	require.NoError(t, fs.Put(context.Background(), "PresDayDC/UHD.jpg", []byte("second"), "image/jpeg"))
+
+	b, err := os.ReadFile(filepath.Join(dir, "PresDayDC", "UHD.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(b))
+
+	entries, err := os.ReadDir(filepath.Join(dir, "PresDayDC"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are cleaned up")
+
+	assert.Equal(t, "https://img.example.com/mirror/PresDayDC/UHD.jpg", fs.URL("PresDayDC/UHD.jpg"))
+	assert.Equal(t, "https://img.example.com/mirror/a%20b/UHD.jpg", fs.URL("a b/UHD.jpg"))
+}
+
+func TestFS_Put_RejectsEscapingKeys(t *testing.T) {
+	fs := &blob.FS{Dir: t.TempDir()}
+	assert.Error(t, fs.Put(context.Background(), "../UHD.jpg", []byte("x"), "image/jpeg"))
+	assert.Error(t, fs.Put(context.Background(), "/etc/UHD.jpg", []byte("x"), "image/jpeg"))
+}
@@ -0,0 +1,199 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	imgpkg "api/internal/updater/image"
+	"api/internal/updater/translate"
+)
+
+// DryRunOptions configures DryRun.
+type DryRunOptions struct {
+	// Markets to fetch, defaults to all English and non-English markets.
+	Markets []string
+	// Annotate downloads, translates, annotates and locates new images so the report shows the full document.
+	// Near-duplicates are only detected when it is set.
+	Annotate bool
+}
+
+// Report lists the changes Update would make.
+type Report struct {
+	Fetched           int           `json:"fetched"`
+	New               []ReportEntry `json:"new"`
+	Rejected          []ReportEntry `json:"rejected"`
+	DuplicateMerges   []ReportEntry `json:"duplicateMerges"`
+	MarketUpgrades    []ReportEntry `json:"marketUpgrades"`
+	URLBaseRepairs    []ReportEntry `json:"urlBaseRepairs"`
+	ResolutionUpdates []ReportEntry `json:"resolutionUpdates"`
+	HistoryUpdates    []ReportEntry `json:"historyUpdates"` // New appearances or localized titles
+	ContentChanges    []ReportEntry `json:"contentChanges"` // Images Bing replaced, by their hsh
+	MetadataUpdates   []ReportEntry `json:"metadataUpdates"`
+}
+
+// ReportEntry is a single image in a Report.
+type ReportEntry struct {
+	ID     string       `json:"id"`
+	Market string       `json:"market"`
+	Image  imgpkg.Image `json:"image"`
+	Diffs  []FieldDiff  `json:"diffs,omitempty"`
+}
+
+// FieldDiff is a field whose stored value would change.
+type FieldDiff struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// DryRun performs the same fetch, dedupe and existence checks as Update, but writes nothing.
+func (u *Updater) DryRun(ctx context.Context, opts DryRunOptions) (*Report, error) {
+	markets := opts.Markets
+	if len(markets) == 0 {
+		markets = append(ENMarkets, nonENMarkets...)
+	}
+
+	images := make(map[string]imgpkg.Image)
+	if err := u.fetchAndDedupeImages(ctx, markets, images, nil); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Fetched:           len(images),
+		New:               []ReportEntry{},
+		Rejected:          []ReportEntry{},
+		DuplicateMerges:   []ReportEntry{},
+		MarketUpgrades:    []ReportEntry{},
+		URLBaseRepairs:    []ReportEntry{},
+		ResolutionUpdates: []ReportEntry{},
+		HistoryUpdates:    []ReportEntry{},
+		ContentChanges:    []ReportEntry{},
+		MetadataUpdates:   []ReportEntry{},
+	}
+
+	dups := u.newDuplicates()
+
+	steps := make([]step, 0, len(images))
+	for _, image := range images {
+		s, err := u.plan(ctx, image, dups, opts.Annotate)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+
+	if opts.Annotate {
+		// use cached translations without caching new ones
+		translator := u.translator
+		if c, ok := translator.(*translate.Cached); ok {
+			translator = &translate.Cached{Translator: c.Translator, Cache: translate.ReadOnly{Cache: c.Cache}}
+		}
+		if err := u.translateNew(ctx, translator, steps); err != nil {
+			return nil, err
+		}
+		for i := range steps {
+			if steps[i].change != changeRejected && steps[i].doc.Geohash == "" {
+				if err := u.locate(ctx, &steps[i].doc); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, s := range steps {
+		entry := ReportEntry{ID: s.id, Market: s.market, Image: s.doc}
+		if s.existing != nil {
+			entry.Diffs = diff(*s.existing, s.doc)
+		}
+
+		switch s.change {
+		case changeNew:
+			report.New = append(report.New, entry)
+		case changeRejected:
+			report.Rejected = append(report.Rejected, entry)
+		case changeDuplicate:
+			report.DuplicateMerges = append(report.DuplicateMerges, entry)
+		case changeMarket:
+			report.MarketUpgrades = append(report.MarketUpgrades, entry)
+		case changeURLBase:
+			report.URLBaseRepairs = append(report.URLBaseRepairs, entry)
+		case changeResolutions:
+			report.ResolutionUpdates = append(report.ResolutionUpdates, entry)
+		case changeAppearances, changeLocalized:
+			report.HistoryUpdates = append(report.HistoryUpdates, entry)
+		case changeContent:
+			report.ContentChanges = append(report.ContentChanges, entry)
+		case changeMetadata:
+			report.MetadataUpdates = append(report.MetadataUpdates, entry)
+		}
+	}
+
+	return report, nil
+}
+
+// WriteText writes a human-readable summary of the report.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d images fetched\n", r.Fetched)
+
+	sections := []struct {
+		name    string
+		entries []ReportEntry
+	}{
+		{"new images", r.New},
+		{"rejected images", r.Rejected},
+		{"near-duplicates merged", r.DuplicateMerges},
+		{"market upgrades", r.MarketUpgrades},
+		{"urlBase repairs", r.URLBaseRepairs},
+		{"resolution updates", r.ResolutionUpdates},
+		{"history updates", r.HistoryUpdates},
+		{"content changes", r.ContentChanges},
+		{"metadata updates", r.MetadataUpdates},
+	}
+	for _, s := range sections {
+		fmt.Fprintf(&b, "\n%d %s\n", len(s.entries), s.name)
+		for _, e := range s.entries {
+			fmt.Fprintf(&b, "  %s (%s) %s\n", e.ID, e.Market, e.Image.Title)
+			for _, d := range e.Diffs {
+				fmt.Fprintf(&b, "    %s: %v -> %v\n", d.Field, d.Old, d.New)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// diff returns the fields that differ between two images, named by their JSON tags.
+func diff(old, updated imgpkg.Image) []FieldDiff {
+	var diffs []FieldDiff
+
+	ov := reflect.ValueOf(old)
+	nv := reflect.ValueOf(updated)
+	t := ov.Type()
+	for i := range t.NumField() {
+		o := ov.Field(i).Interface()
+		n := nv.Field(i).Interface()
+		if reflect.DeepEqual(o, n) || (isEmpty(ov.Field(i)) && isEmpty(nv.Field(i))) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		diffs = append(diffs, FieldDiff{Field: name, Old: o, New: n})
+	}
+
+	return diffs
+}
+
+// isEmpty reports whether v is a zero value or an empty map or slice.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,167 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"api/internal/updater/firestore"
+	imgpkg "api/internal/updater/image"
+	"api/internal/updater/phash"
+)
+
+// DuplicatePolicy decides what happens to a new image that is a near-duplicate of a stored one.
+type DuplicatePolicy string
+
+const (
+	// DuplicateFlag stores the image with DuplicateOf set for review.
+	DuplicateFlag DuplicatePolicy = "flag"
+	// DuplicateMerge merges the image into the stored one, keeping the earliest date and all markets.
+	DuplicateMerge DuplicatePolicy = "merge"
+)
+
+// duplicates finds near-duplicates by perceptual hash.
+// Stored hashes are loaded on first use, so runs without new images don't read the whole collection.
+type duplicates struct {
+	u           *Updater
+	hashes      map[string]uint64
+	planned     map[string]imgpkg.Image // documents to be written during this run, by ID
+	maxDistance int
+}
+
+func (u *Updater) newDuplicates() *duplicates {
+	return &duplicates{u: u, planned: map[string]imgpkg.Image{}, maxDistance: u.duplicateDistance}
+}
+
+// find returns the stored image closest to hash within maxDistance, or nil.
+func (d *duplicates) find(ctx context.Context, id string, hash uint64) (*imgpkg.Image, error) {
+	if d.hashes == nil {
+		stored, err := d.u.firestoreClient.ListHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		d.hashes = make(map[string]uint64, len(stored))
+		for k, v := range stored {
+			if h, err := phash.Parse(v); err == nil {
+				d.hashes[k] = h
+			}
+		}
+	}
+
+	bestID, best := "", d.maxDistance+1
+	for k, v := range d.hashes {
+		if k == id {
+			continue
+		}
+		if dist := phash.Distance(hash, v); dist < best {
+			bestID, best = k, dist
+		}
+	}
+	if bestID == "" {
+		return nil, nil
+	}
+
+	// images planned during this run aren't stored yet
+	if image, ok := d.planned[bestID]; ok {
+		return &image, nil
+	}
+	return d.u.firestoreClient.Get(ctx, bestID)
+}
+
+// add records a new image to be stored during this run.
+func (d *duplicates) add(image imgpkg.Image, hash uint64) {
+	d.planned[image.ID] = image
+	if d.hashes != nil {
+		d.hashes[image.ID] = hash
+	}
+}
+
+// merged records that an image was merged into, so that later duplicates are merged into the result.
+func (d *duplicates) merged(image imgpkg.Image) {
+	d.planned[image.ID] = image
+}
+
+// foldDuplicates keeps a single step per document, as near-duplicates merged into an image of the same run
+// plan to write it again. The document of the last such step includes the earlier ones.
+func foldDuplicates(steps []step) []step {
+	first := make(map[string]int, len(steps))
+	folded := steps[:0]
+	for _, s := range steps {
+		if i, ok := first[s.doc.ID]; ok {
+			folded[i].doc = s.doc
+			continue
+		}
+		first[s.doc.ID] = len(folded)
+		folded = append(folded, s)
+	}
+	return folded
+}
+
+// BackfillHashes downloads stored images without a perceptual hash, or all of them if force is set, and saves it,
+// so that new images are compared with the whole archive. It returns the IDs of the images that were updated.
+// Images that fail to download are logged and skipped.
+func (u *Updater) BackfillHashes(ctx context.Context, force bool) ([]string, error) {
+	updated := []string{}
+
+	q := firestore.ListQuery{Limit: reannotatePageSize}
+	for {
+		images, err := u.firestoreClient.List(ctx, q)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, image := range images {
+			if image.PHash != "" && !force {
+				continue
+			}
+
+			imgBytes, err := u.download(ctx, image.URL())
+			if err != nil {
+				if ctx.Err() != nil {
+					return updated, ctx.Err()
+				}
+				log.Printf("warning: %s: %v", image.ID, err)
+				continue
+			}
+			hash, err := phash.FromBytes(imgBytes)
+			if err != nil {
+				log.Printf("warning: %s: %v", image.ID, err)
+				continue
+			}
+			image.PHash = phash.Format(hash)
+
+			if err := u.firestoreClient.UpdateHash(ctx, image); err != nil {
+				return updated, err
+			}
+			updated = append(updated, image.ID)
+		}
+
+		if len(images) < q.Limit {
+			break
+		}
+		last := images[len(images)-1]
+		q.StartAfterDate, q.StartAfterID = last.Date, last.ID
+	}
+
+	fmt.Printf("%d images hashed\n", len(updated))
+	return updated, nil
+}
+
+// mergeDuplicate merges a near-duplicate into the stored original, keeping the earliest date and all markets.
+func mergeDuplicate(original, image imgpkg.Image) imgpkg.Image {
+	original.Appearances = mergeAppearances(history(original), image.Appearances)
+	original.Markets = appearanceMarkets(original.Appearances)
+	original.Localized = mergeLocalized(localizedHistory(original), image.Localized)
+
+	if image.Date < original.Date {
+		original.Date = image.Date
+	}
+
+	if !slices.Contains(original.Aliases, image.ID) {
+		original.Aliases = append(original.Aliases, image.ID)
+	}
+
+	return original
+}
@@ -0,0 +1,56 @@
+package firestore
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Checkpoint records how far a resumable job got through the collection.
+type Checkpoint struct {
+	Date      int       `firestore:"date"`
+	ID        string    `firestore:"id"`
+	Processed int       `firestore:"processed"` // Images processed so far, not counting skipped ones
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+// GetCheckpoint returns the named checkpoint, or nil if it doesn't exist.
+func (c *Client) GetCheckpoint(ctx context.Context, collection, name string) (*Checkpoint, error) {
+	dsnap, err := c.firestore.Collection(collection).Doc(name).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cp Checkpoint
+	if err := dsnap.DataTo(&cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (c *Client) SetCheckpoint(ctx context.Context, collection, name string, cp Checkpoint) error {
+	return c.set(ctx, c.firestore.Collection(collection).Doc(name), cp)
+}
+
+func (c *Client) DeleteCheckpoint(ctx context.Context, collection, name string) error {
+	return c.delete(ctx, c.firestore.Collection(collection).Doc(name))
+}
+
+// AnnotationAudit records a change made by re-annotating an image.
+type AnnotationAudit struct {
+	ID        string             `firestore:"id"`
+	At        time.Time          `firestore:"at"`
+	OldTags   map[string]float32 `firestore:"oldTags"`
+	NewTags   map[string]float32 `firestore:"newTags"`
+	OldColors []string           `firestore:"oldColors"`
+	NewColors []string           `firestore:"newColors"`
+}
+
+func (c *Client) AddAudit(ctx context.Context, collection string, a AnnotationAudit) error {
+	return c.set(ctx, c.firestore.Collection(collection).NewDoc(), a)
+}
@@ -2,27 +2,96 @@ package firestore
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"api/internal/updater/image"
 	"cloud.google.com/go/firestore"
 
-	firebase "firebase.google.com/go"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func NewClient(ctx context.Context, collection string, app *firebase.App) (*Client, error) {
-	firestore, err := app.Firestore(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Client{collection: collection, firestore: firestore}, nil
+func NewClient(collection string, firestore *firestore.Client) *Client {
+	return &Client{collection: collection, firestore: firestore}
 }
 
 type Client struct {
 	collection string
 	firestore  *firestore.Client
+	fence      Fence // nil if writes aren't fenced
+}
+
+// Fence runs fn in a transaction that fails if the writer no longer holds its lease.
+type Fence func(ctx context.Context, fn func(tx *firestore.Transaction) error) error
+
+// Fenced returns a client making every write through fence, except job records,
+// which are saved even after the lease is lost.
+func (c *Client) Fenced(fence Fence) *Client {
+	fenced := *c
+	fenced.fence = fence
+	return &fenced
+}
+
+// CheckedFence returns a fence calling check before each write, for leases that aren't stored in Firestore.
+func (c *Client) CheckedFence(check func(ctx context.Context) error) Fence {
+	return func(ctx context.Context, fn func(tx *firestore.Transaction) error) error {
+		if err := check(ctx); err != nil {
+			return err
+		}
+		return c.firestore.RunTransaction(ctx, func(_ context.Context, tx *firestore.Transaction) error {
+			return fn(tx)
+		})
+	}
+}
+
+// update changes fields of a document, through the fence if there is one.
+func (c *Client) update(ctx context.Context, ref *firestore.DocumentRef, updates []firestore.Update) error {
+	if c.fence != nil {
+		return c.fence(ctx, func(tx *firestore.Transaction) error { return tx.Update(ref, updates) })
+	}
+	_, err := ref.Update(ctx, updates)
+	return err
+}
+
+// set writes a document, through the fence if there is one.
+func (c *Client) set(ctx context.Context, ref *firestore.DocumentRef, data any, opts ...firestore.SetOption) error {
+	if c.fence != nil {
+		return c.fence(ctx, func(tx *firestore.Transaction) error { return tx.Set(ref, data, opts...) })
+	}
+	_, err := ref.Set(ctx, data, opts...)
+	return err
+}
+
+// delete deletes a document, through the fence if there is one.
+func (c *Client) delete(ctx context.Context, ref *firestore.DocumentRef) error {
+	if c.fence != nil {
+		return c.fence(ctx, func(tx *firestore.Transaction) error { return tx.Delete(ref) })
+	}
+	_, err := ref.Delete(ctx)
+	return err
+}
+
+// fencedBatchSize keeps each fenced transaction within Firestore's limit of 500 writes.
+const fencedBatchSize = 400
+
+// writeFenced makes writes through the fence, in transactions of up to fencedBatchSize writes.
+func (c *Client) writeFenced(ctx context.Context, writes []func(tx *firestore.Transaction) error) error {
+	for batch := range slices.Chunk(writes, fencedBatchSize) {
+		err := c.fence(ctx, func(tx *firestore.Transaction) error {
+			for _, write := range batch {
+				if err := write(tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *Client) Get(ctx context.Context, ID string) (*image.Image, error) {
@@ -46,6 +115,157 @@ func (c *Client) Get(ctx context.Context, ID string) (*image.Image, error) {
 	return &result, nil
 }
 
-func (c *Client) Upsert(ctx context.Context, img image.Image) (*firestore.WriteResult, error) {
-	return c.firestore.Collection(c.collection).Doc(img.ID).Set(ctx, img)
+// GetByAlias returns the image a near-duplicate with the given ID was merged into, or nil.
+func (c *Client) GetByAlias(ctx context.Context, ID string) (*image.Image, error) {
+	iter := c.firestore.Collection(c.collection).Where("aliases", "array-contains", ID).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	dsnap, err := iter.Next()
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var result image.Image
+	if err := dsnap.DataTo(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListHashes returns the perceptual hash of every image that has one, keyed by ID.
+func (c *Client) ListHashes(ctx context.Context) (map[string]string, error) {
+	dsnap, err := c.firestore.Collection(c.collection).Where("phash", ">", "").Select("phash").Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]string, len(dsnap))
+	for _, doc := range dsnap {
+		if h, ok := doc.Data()["phash"].(string); ok {
+			hashes[doc.Ref.ID] = h
+		}
+	}
+
+	return hashes, nil
+}
+
+// ListQuery selects images ordered by date and ID.
+type ListQuery struct {
+	FromDate       int // Inclusive, ignored if zero
+	ToDate         int // Inclusive, ignored if zero
+	StartAfterDate int
+	StartAfterID   string
+	Limit          int
+}
+
+// List returns a page of images matching the query.
+func (c *Client) List(ctx context.Context, q ListQuery) ([]image.Image, error) {
+	query := c.firestore.Collection(c.collection).
+		OrderBy("date", firestore.Asc).
+		OrderBy("id", firestore.Asc).
+		Limit(q.Limit)
+
+	if q.FromDate != 0 {
+		query = query.Where("date", ">=", q.FromDate)
+	}
+	if q.ToDate != 0 {
+		query = query.Where("date", "<=", q.ToDate)
+	}
+	if q.StartAfterID != "" {
+		query = query.StartAfter(q.StartAfterDate, q.StartAfterID)
+	}
+
+	dsnap, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]image.Image, 0, len(dsnap))
+	for _, doc := range dsnap {
+		var img image.Image
+		if err := doc.DataTo(&img); err != nil {
+			return nil, err
+		}
+		if img.Tags == nil {
+			img.Tags = make(map[string]float32)
+		}
+		images = append(images, img)
+	}
+
+	return images, nil
+}
+
+// UpdateAnnotations replaces only the annotated fields of an image, leaving other fields untouched.
+func (c *Client) UpdateAnnotations(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "tags", Value: img.Tags},
+		{Path: "tagsOrdered", Value: img.TagsOrdered},
+		{Path: "colors", Value: img.Colors},
+		{Path: "landmarks", Value: img.Landmarks},
+		{Path: "landmarkSlugs", Value: img.LandmarkSlugs},
+		{Path: "webEntities", Value: img.WebEntities},
+		{Path: "safeSearch", Value: img.SafeSearch},
+		{Path: "place", Value: orDelete(img.Place)},
+		{Path: "lat", Value: orDelete(img.Lat)},
+		{Path: "lng", Value: orDelete(img.Lng)},
+		{Path: "geohash", Value: orDelete(img.Geohash)},
+		{Path: "country", Value: orDelete(img.Country)},
+	})
+}
+
+// UpdatePlaceholders replaces only the placeholders of an image.
+func (c *Client) UpdatePlaceholders(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "blurHash", Value: img.BlurHash},
+		{Path: "lqip", Value: img.LQIP},
+	})
+}
+
+// UpdateResolutions replaces only the resolution variants and orientations of an image.
+func (c *Client) UpdateResolutions(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "resolutions", Value: img.Resolutions},
+		{Path: "landscape", Value: orDelete(img.Landscape)},
+		{Path: "portrait", Value: orDelete(img.Portrait)},
+	})
+}
+
+// UpdateHash replaces only the perceptual hash of an image.
+func (c *Client) UpdateHash(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "phash", Value: img.PHash},
+	})
+}
+
+// UpdateVariants replaces only the measured variants and orientations of an image.
+func (c *Client) UpdateVariants(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "variants", Value: img.Variants},
+		{Path: "landscape", Value: orDelete(img.Landscape)},
+		{Path: "portrait", Value: orDelete(img.Portrait)},
+	})
+}
+
+// UpdateMirrors replaces only the mirrored copies of an image.
+func (c *Client) UpdateMirrors(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "mirrors", Value: img.Mirrors},
+	})
+}
+
+func (c *Client) Upsert(ctx context.Context, img image.Image) error {
+	return c.set(ctx, c.firestore.Collection(c.collection).Doc(img.ID), img)
+}
+
+// orDelete returns v, or the Delete sentinel if v is a zero value, so that updates omit empty fields like Set does.
+func orDelete[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return firestore.Delete
+	}
+	return v
 }
@@ -0,0 +1,77 @@
+package firestore
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// Job statuses.
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobPartial   = "partial" // Some images failed, the others were stored
+	JobFailed    = "failed"
+)
+
+// Job records an updater run, one document per run.
+type Job struct {
+	ID        string         `firestore:"id"`
+	Type      string         `firestore:"type"`    // Message type, e.g. "update"
+	Trigger   string         `firestore:"trigger"` // What started the run, e.g. "schedule"
+	Status    string         `firestore:"status"`
+	StartedAt time.Time      `firestore:"startedAt"`
+	EndedAt   *time.Time     `firestore:"endedAt,omitempty"`
+	Markets   map[string]int `firestore:"markets,omitempty"` // Wallpapers Bing listed per market
+	New       []string       `firestore:"new"`
+	Updated   []string       `firestore:"updated"`
+	Failed    []JobFailure   `firestore:"failed"`
+	Error     string         `firestore:"error,omitempty"` // Why the run failed
+}
+
+// JobFailure is an image a job failed to store.
+type JobFailure struct {
+	ID    string `firestore:"id"`
+	Error string `firestore:"error"`
+}
+
+func (c *Client) SetJob(ctx context.Context, collection string, job Job) error {
+	_, err := c.firestore.Collection(collection).Doc(job.ID).Set(ctx, job)
+	return err
+}
+
+// DeleteJobsBefore deletes the jobs started before t, returning how many there were.
+func (c *Client) DeleteJobsBefore(ctx context.Context, collection string, t time.Time) (int, error) {
+	iter := c.firestore.Collection(collection).Where("startedAt", "<", t).Documents(ctx)
+	defer iter.Stop()
+
+	bw := c.firestore.BulkWriter(ctx)
+	var jobs []*firestore.BulkWriterJob
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			bw.End()
+			return 0, err
+		}
+
+		job, err := bw.Delete(doc.Ref)
+		if err != nil {
+			bw.End()
+			return 0, err
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return 0, err
+		}
+	}
+	return len(jobs), nil
+}
@@ -0,0 +1,104 @@
+package firestore
+
+import (
+	"context"
+
+	"api/internal/updater/image"
+
+	"cloud.google.com/go/firestore"
+)
+
+// Photographer counts the wallpapers credited to a photographer, one document per slug.
+type Photographer struct {
+	Slug   string `firestore:"slug"`
+	Name   string `firestore:"name"`
+	Agency string `firestore:"agency,omitempty"` // Agency of the latest wallpaper
+	Count  int    `firestore:"count"`
+}
+
+// AddPhotographer counts one more wallpaper for the image's photographer.
+func (c *Client) AddPhotographer(ctx context.Context, collection string, img image.Image) error {
+	data := map[string]any{
+		"slug":  img.PhotographerSlug,
+		"name":  img.Photographer,
+		"count": firestore.Increment(1),
+	}
+	if img.Agency != "" {
+		data["agency"] = img.Agency
+	}
+	return c.set(ctx, c.firestore.Collection(collection).Doc(img.PhotographerSlug), data, firestore.MergeAll)
+}
+
+// SetPhotographers replaces the photographers collection, deleting photographers that are no longer credited.
+func (c *Client) SetPhotographers(ctx context.Context, collection string, photographers []Photographer) error {
+	refs, err := c.firestore.Collection(collection).DocumentRefs(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(photographers))
+	for _, p := range photographers {
+		keep[p.Slug] = true
+	}
+
+	if c.fence != nil {
+		return c.setPhotographersFenced(ctx, collection, photographers, refs, keep)
+	}
+
+	bw := c.firestore.BulkWriter(ctx)
+
+	jobs := make([]*firestore.BulkWriterJob, 0, len(photographers))
+	for _, p := range photographers {
+		job, err := bw.Set(c.firestore.Collection(collection).Doc(p.Slug), p)
+		if err != nil {
+			bw.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	for _, ref := range refs {
+		if keep[ref.ID] {
+			continue
+		}
+		job, err := bw.Delete(ref)
+		if err != nil {
+			bw.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setPhotographersFenced writes photographers in fenced transactions of up to fencedBatchSize writes.
+func (c *Client) setPhotographersFenced(ctx context.Context, collection string, photographers []Photographer, refs []*firestore.DocumentRef, keep map[string]bool) error {
+	var writes []func(tx *firestore.Transaction) error
+	for _, p := range photographers {
+		ref := c.firestore.Collection(collection).Doc(p.Slug)
+		writes = append(writes, func(tx *firestore.Transaction) error { return tx.Set(ref, p) })
+	}
+	for _, ref := range refs {
+		if !keep[ref.ID] {
+			writes = append(writes, func(tx *firestore.Transaction) error { return tx.Delete(ref) })
+		}
+	}
+
+	return c.writeFenced(ctx, writes)
+}
+
+// UpdateAttribution replaces only the photographer and agency of an image.
+func (c *Client) UpdateAttribution(ctx context.Context, img image.Image) error {
+	return c.update(ctx, c.firestore.Collection(c.collection).Doc(img.ID), []firestore.Update{
+		{Path: "photographer", Value: orDelete(img.Photographer)},
+		{Path: "photographerSlug", Value: orDelete(img.PhotographerSlug)},
+		{Path: "agency", Value: orDelete(img.Agency)},
+		{Path: "agencySlug", Value: orDelete(img.AgencySlug)},
+	})
+}
@@ -0,0 +1,81 @@
+package firestore
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+)
+
+// TranslationCache stores translations in a Firestore collection, one document per key.
+type TranslationCache struct {
+	collection string
+	client     *Client
+	firestore  *firestore.Client
+}
+
+// TranslationCache returns a cache backed by the given collection, whose writes go through the client's fence.
+func (c *Client) TranslationCache(collection string) *TranslationCache {
+	return &TranslationCache{collection: collection, client: c, firestore: c.firestore}
+}
+
+type translation struct {
+	Text string `firestore:"text"`
+}
+
+func (t *TranslationCache) Get(ctx context.Context, keys []string) (map[string]string, error) {
+	refs := make([]*firestore.DocumentRef, len(keys))
+	for i, k := range keys {
+		refs[i] = t.firestore.Collection(t.collection).Doc(k)
+	}
+
+	dsnaps, err := t.firestore.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(keys))
+	for _, dsnap := range dsnaps {
+		if !dsnap.Exists() {
+			continue
+		}
+
+		var tr translation
+		if err := dsnap.DataTo(&tr); err != nil {
+			return nil, err
+		}
+		out[dsnap.Ref.ID] = tr.Text
+	}
+
+	return out, nil
+}
+
+func (t *TranslationCache) Set(ctx context.Context, translations map[string]string) error {
+	if t.client.fence != nil {
+		writes := make([]func(tx *firestore.Transaction) error, 0, len(translations))
+		for k, v := range translations {
+			ref := t.firestore.Collection(t.collection).Doc(k)
+			writes = append(writes, func(tx *firestore.Transaction) error { return tx.Set(ref, translation{Text: v}) })
+		}
+		return t.client.writeFenced(ctx, writes)
+	}
+
+	bw := t.firestore.BulkWriter(ctx)
+
+	jobs := make([]*firestore.BulkWriterJob, 0, len(translations))
+	for k, v := range translations {
+		job, err := bw.Set(t.firestore.Collection(t.collection).Doc(k), translation{Text: v})
+		if err != nil {
+			bw.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"api/internal/geo"
+	imgpkg "api/internal/updater/image"
+)
+
+// defaultMaxDistance is how far in km a location may be from a place for Reverse to return it.
+const defaultMaxDistance = 50
+
+// Place is a named location.
+type Place struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Lat     float64  `json:"lat"`
+	Lng     float64  `json:"lng"`
+	Country string   `json:"country"` // ISO 3166-1 alpha-2 code, e.g. "US"
+}
+
+// Geocoder looks up places by name and location.
+type Geocoder interface {
+	// Geocode returns the place best matching name, or nil if it is unknown.
+	Geocode(ctx context.Context, name string) (*Place, error)
+	// Reverse returns the place nearest a location, or nil if none is nearby.
+	Reverse(ctx context.Context, lat, lng float64) (*Place, error)
+}
+
+// Gazetteer geocodes offline from a fixed list of places.
+type Gazetteer struct {
+	MaxDistance float64 // km, defaults to 50
+
+	places []Place
+	names  map[string]int // Slug of each name and alias to its index in places
+}
+
+func NewGazetteer(places []Place) *Gazetteer {
+	g := &Gazetteer{places: places, names: make(map[string]int)}
+	for i, p := range places {
+		for _, name := range append([]string{p.Name}, p.Aliases...) {
+			if _, ok := g.names[imgpkg.Slug(name)]; !ok {
+				g.names[imgpkg.Slug(name)] = i
+			}
+		}
+	}
+	return g
+}
+
+// LoadGazetteer reads places from a JSON file containing an array of places.
+func LoadGazetteer(path string) (*Gazetteer, error) {
+	b, err := os.ReadFile(path) // #nosec G304 -- path comes from configuration
+	if err != nil {
+		return nil, err
+	}
+
+	var places []Place
+	if err := json.Unmarshal(b, &places); err != nil {
+		return nil, fmt.Errorf("failed to parse gazetteer %s: %w", path, err)
+	}
+	return NewGazetteer(places), nil
+}
+
+// Geocode matches name, and failing that the part of it before the first comma, against the names and aliases
+// of each place, ignoring case and punctuation.
+func (g *Gazetteer) Geocode(_ context.Context, name string) (*Place, error) {
+	first, _, _ := strings.Cut(name, ",")
+	for _, n := range []string{name, first} {
+		if i, ok := g.names[imgpkg.Slug(n)]; ok {
+			p := g.places[i]
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *Gazetteer) Reverse(_ context.Context, lat, lng float64) (*Place, error) {
+	maxDistance := g.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = defaultMaxDistance
+	}
+
+	var nearest *Place
+	for i, p := range g.places {
+		if d := geo.Distance(lat, lng, p.Lat, p.Lng); d <= maxDistance {
+			nearest, maxDistance = &g.places[i], d
+		}
+	}
+	if nearest == nil {
+		return nil, nil
+	}
+	p := *nearest
+	return &p, nil
+}
+
+// Noop finds no places.
+type Noop struct{}
+
+func (Noop) Geocode(context.Context, string) (*Place, error) {
+	return nil, nil
+}
+
+func (Noop) Reverse(context.Context, float64, float64) (*Place, error) {
+	return nil, nil
+}
+
+// Candidates returns the place names a wallpaper title may refer to, most specific first.
+// Bing titles name the subject followed by where it is, e.g. "Humpback whales, Maui, Hawaii"
+// gives "Humpback whales", "Maui, Hawaii" and "Hawaii".
+func Candidates(title string) []string {
+	var parts []string
+	for _, p := range strings.Split(title, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	candidates := make([]string, 0, len(parts))
+	if len(parts) > 0 {
+		candidates = append(candidates, parts[0])
+	}
+	for i := 1; i < len(parts); i++ {
+		candidates = append(candidates, strings.Join(parts[i:], ", "))
+	}
+	return candidates
+}
@@ -0,0 +1,58 @@
+package geocode_test
+
+import (
+	"context"
+	"testing"
+
+	"api/internal/updater/geocode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var places = []geocode.Place{
+	{Name: "Washington, DC", Aliases: []string{"Washington D.C."}, Lat: 38.9072, Lng: -77.0369, Country: "US"},
+	{Name: "Maui", Lat: 20.7984, Lng: -156.3319, Country: "US"},
+	{Name: "Paris", Lat: 48.8566, Lng: 2.3522, Country: "FR"},
+}
+
+func TestGazetteer_Geocode(t *testing.T) {
+	g := geocode.NewGazetteer(places)
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "Washington, D.C.")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, "US", p.Country)
+
+	p, err = g.Geocode(ctx, "Maui, Hawaii")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, "Maui", p.Name)
+
+	p, err = g.Geocode(ctx, "Humpback whales")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestGazetteer_Reverse(t *testing.T) {
+	g := geocode.NewGazetteer(places)
+	ctx := context.Background()
+
+	// the Eiffel Tower
+	p, err := g.Reverse(ctx, 48.8584, 2.2945)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, "FR", p.Country)
+
+	// the middle of the Atlantic
+	p, err = g.Reverse(ctx, 30, -40)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestCandidates(t *testing.T) {
+	assert.Equal(t, []string{"Humpback whales", "Maui, Hawaii", "Hawaii"}, geocode.Candidates("Humpback whales, Maui, Hawaii"))
+	assert.Equal(t, []string{"Grand Canyon"}, geocode.Candidates("Grand Canyon"))
+	assert.Empty(t, geocode.Candidates(""))
+}
@@ -0,0 +1,94 @@
+package image
+
+import (
+	"strings"
+)
+
+// agencies maps the slugs of known agency names, including short and Chinese forms, to their canonical name.
+var agencies = map[string]string{
+	"getty-images":            "Getty Images",
+	"getty":                   "Getty Images",
+	"盖蒂图片社":                   "Getty Images",
+	"shutterstock":            "Shutterstock",
+	"alamy":                   "Alamy",
+	"alamy-stock-photo":       "Alamy",
+	"minden-pictures":         "Minden Pictures",
+	"minden":                  "Minden Pictures",
+	"offset":                  "Offset",
+	"offset-by-shutterstock":  "Offset",
+	"istockphoto":             "iStockphoto",
+	"istock":                  "iStockphoto",
+	"nature-picture-library":  "Nature Picture Library",
+	"naturepl-com":            "Nature Picture Library",
+	"amazing-aerial-agency":   "Amazing Aerial Agency",
+	"cavan-images":            "Cavan Images",
+	"danita-delimont":         "Danita Delimont",
+	"age-fotostock":           "age fotostock",
+	"tandem-stills-motion":    "Tandem Stills + Motion",
+	"design-pics":             "Design Pics",
+	"superstock":              "SuperStock",
+	"masterfile":              "Masterfile",
+	"robertharding":           "robertharding",
+	"plainpicture":            "plainpicture",
+	"adobe-stock":             "Adobe Stock",
+	"视觉中国":                    "Visual China Group",
+	"visual-china-group":      "Visual China Group",
+	"500px":                   "500px",
+	"aurora-photos":           "Aurora Photos",
+	"biosphoto":               "Biosphoto",
+	"nasa":                    "NASA",
+	"wild-wonders-of-europe":  "Wild Wonders of Europe",
+	"national-geographic":     "National Geographic",
+	"national-geographic-pro": "National Geographic",
+}
+
+// ParseAttribution splits a copyright like "AevanStock/Shutterstock" into the photographer and the agency.
+// It handles "Photographer via Agency", a lone agency or photographer, and the fullwidth slashes and
+// "图片来源" (image source) prefix of Chinese markets. Known agencies are returned by their canonical name.
+func ParseAttribution(copyright string) (photographer, agency string) {
+	s := strings.ReplaceAll(copyright, "／", "/")
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "©"))
+	for _, prefix := range []string{"图片来源：", "图片来源:"} {
+		s = strings.TrimSpace(strings.TrimPrefix(s, prefix))
+	}
+
+	var via string
+	if i := strings.Index(strings.ToLower(s), " via "); i >= 0 {
+		s, via = s[:i], strings.TrimSpace(s[i+len(" via "):])
+	}
+
+	var parts []string
+	for _, p := range strings.Split(s, "/") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	switch {
+	case len(parts) == 0:
+	case isAgency(parts[0]):
+		// e.g. "Getty Images/iStockphoto" names no photographer
+		agency = canonicalAgency(parts[0])
+	case len(parts) == 1:
+		photographer = parts[0]
+	default:
+		photographer, agency = parts[0], canonicalAgency(parts[1])
+	}
+
+	if agency == "" && via != "" {
+		agency = canonicalAgency(via)
+	}
+	return photographer, agency
+}
+
+func isAgency(name string) bool {
+	_, ok := agencies[Slug(name)]
+	return ok
+}
+
+func canonicalAgency(name string) string {
+	if a, ok := agencies[Slug(name)]; ok {
+		return a
+	}
+	return name
+}
@@ -0,0 +1,55 @@
+package image_test
+
+import (
+	"testing"
+
+	"api/internal/updater/image"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttribution(t *testing.T) {
+	tests := []struct {
+		copyright    string
+		photographer string
+		agency       string
+	}{
+		{"AevanStock/Shutterstock", "AevanStock", "Shutterstock"},
+		{"Flip Nicklin/Minden Pictures", "Flip Nicklin", "Minden Pictures"},
+		{"Tui De Roy/Minden Pictures/Getty Images", "Tui De Roy", "Minden Pictures"},
+		{"Sergio Pitamitz/Getty", "Sergio Pitamitz", "Getty Images"},
+		{"Getty Images", "", "Getty Images"},
+		{"Getty Images/iStockphoto", "", "Getty Images"},
+		{"Cavan Images via Offset", "", "Cavan Images"},
+		{"Jeff Foott via Getty Images", "Jeff Foott", "Getty Images"},
+		{"Ben Pipe", "Ben Pipe", ""},
+		{"Jeff Foott／Minden Pictures", "Jeff Foott", "Minden Pictures"},
+		{"© 图片来源：视觉中国", "", "Visual China Group"},
+		{"Ingo Arndt/naturepl.com", "Ingo Arndt", "Nature Picture Library"},
+		{"Some One/Local Agency", "Some One", "Local Agency"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.copyright, func(t *testing.T) {
+			photographer, agency := image.ParseAttribution(tt.copyright)
+			assert.Equal(t, tt.photographer, photographer)
+			assert.Equal(t, tt.agency, agency)
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "washington-monument", image.Slug("Washington Monument"))
+	assert.Equal(t, "tandem-stills-motion", image.Slug("Tandem Stills + Motion"))
+	assert.Equal(t, "naturepl-com", image.Slug(" naturepl.com "))
+	assert.Equal(t, "视觉中国", image.Slug("视觉中国"))
+}
+
+func TestImage_SetAttribution(t *testing.T) {
+	img := image.Image{Copyright: "Flip Nicklin/Minden Pictures"}
+	img.SetAttribution()
+	assert.Equal(t, "Flip Nicklin", img.Photographer)
+	assert.Equal(t, "flip-nicklin", img.PhotographerSlug)
+	assert.Equal(t, "Minden Pictures", img.Agency)
+	assert.Equal(t, "minden-pictures", img.AgencySlug)
+}
@@ -2,14 +2,28 @@ package image
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
+	"api/internal/resolution"
 	"api/internal/updater/bing"
 )
 
 const bingURL = "https://www.bing.com"
 
+// Resolutions are the variants Bing may publish for an image, largest first.
+var Resolutions = []string{
+	"UHD",
+	"1920x1200",
+	"1920x1080",
+	"1366x768",
+	"1280x768",
+	"1080x1920",
+	"768x1280",
+}
+
 // RGB represents a color as [R, G, B] values (0-255).
 type RGB [3]int
 
@@ -18,6 +32,80 @@ func (c RGB) ToHex() string {
 	return fmt.Sprintf("#%02X%02X%02X", c[0], c[1], c[2])
 }
 
+// ParseHex parses a hex string such as "#4A90D9".
+func ParseHex(s string) (RGB, error) {
+	var c RGB
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &c[0], &c[1], &c[2]); err != nil {
+		return RGB{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+	}
+	return c, nil
+}
+
+// Appearance is a market and date an image was published on, with its localized title and copyright.
+type Appearance struct {
+	Market    string `json:"market" firestore:"market"`
+	Date      int    `json:"date" firestore:"date"`
+	Title     string `json:"title,omitempty" firestore:"title,omitempty"`
+	Copyright string `json:"copyright,omitempty" firestore:"copyright,omitempty"`
+	Headline  string `json:"headline,omitempty" firestore:"headline,omitempty"`
+}
+
+// Localization is a title, copyright and headline in one language.
+type Localization struct {
+	Title             string `json:"title" firestore:"title"`
+	Copyright         string `json:"copyright,omitempty" firestore:"copyright,omitempty"`
+	Headline          string `json:"headline,omitempty" firestore:"headline,omitempty"`
+	MachineTranslated bool   `json:"machineTranslated,omitempty" firestore:"machineTranslated,omitempty"`
+}
+
+// Hotspot is a point of interest Bing marks on the homepage image.
+type Hotspot struct {
+	Desc  string `json:"desc" firestore:"desc"`
+	Link  string `json:"link" firestore:"link"`
+	Query string `json:"query,omitempty" firestore:"query,omitempty"`
+	LocX  int    `json:"locX" firestore:"locX"`
+	LocY  int    `json:"locY" firestore:"locY"`
+}
+
+// Landmark is a recognised place in an image.
+type Landmark struct {
+	Name  string  `json:"name" firestore:"name"`
+	Score float32 `json:"score" firestore:"score"`
+	Lat   float64 `json:"lat,omitempty" firestore:"lat,omitempty"`
+	Lng   float64 `json:"lng,omitempty" firestore:"lng,omitempty"`
+}
+
+// WebEntity is an entity the image is associated with on the web.
+type WebEntity struct {
+	Description string  `json:"description" firestore:"description"`
+	Score       float32 `json:"score" firestore:"score"`
+}
+
+// SafeSearch holds the likelihood of each kind of sensitive content, e.g. "VERY_UNLIKELY".
+type SafeSearch struct {
+	Adult    string `json:"adult" firestore:"adult"`
+	Spoof    string `json:"spoof" firestore:"spoof"`
+	Medical  string `json:"medical" firestore:"medical"`
+	Violence string `json:"violence" firestore:"violence"`
+	Racy     string `json:"racy" firestore:"racy"`
+}
+
+// Mirror is a copy of a resolution variant in our own storage.
+type Mirror struct {
+	URL    string `json:"url" firestore:"url"`
+	SHA256 string `json:"sha256" firestore:"sha256"` // Hex encoded
+	Size   int    `json:"size" firestore:"size"`     // Bytes
+}
+
+// Variant is the measured size of a resolution variant.
+type Variant struct {
+	Width       int     `json:"width" firestore:"width"`
+	Height      int     `json:"height" firestore:"height"`
+	AspectRatio float64 `json:"aspectRatio" firestore:"aspectRatio"`             // Width divided by height
+	Size        int     `json:"size" firestore:"size"`                           // Bytes
+	Quality     int     `json:"quality,omitempty" firestore:"quality,omitempty"` // Estimated JPEG quality
+}
+
 type Image struct {
 	ID        string `json:"id" firestore:"id"`
 	Title     string `json:"title,omitempty" firestore:"title,omitempty"`
@@ -27,13 +115,96 @@ type Image struct {
 	URLBase   string `json:"urlBase,omitempty" firestore:"urlBase,omitempty"`
 	FullDesc  string `json:"fullDesc,omitempty" firestore:"fullDesc,omitempty"`
 
+	Resolutions []string           `json:"resolutions,omitempty" firestore:"resolutions,omitempty"` // Variants that exist, e.g. "1920x1200"
+	Mirrors     map[string]Mirror  `json:"mirrors,omitempty" firestore:"mirrors,omitempty"`         // Keyed by resolution
+	Variants    map[string]Variant `json:"variants,omitempty" firestore:"variants,omitempty"`       // Keyed by resolution
+	Landscape   bool               `json:"landscape,omitempty" firestore:"landscape,omitempty"`     // Whether a landscape variant exists
+	Portrait    bool               `json:"portrait,omitempty" firestore:"portrait,omitempty"`       // Whether a portrait (mobile) variant exists
+	Markets     []string           `json:"markets,omitempty" firestore:"markets,omitempty"`
+	Appearances []Appearance       `json:"appearances,omitempty" firestore:"appearances,omitempty"`
+
+	Localized map[string]Localization `json:"localized,omitempty" firestore:"localized,omitempty"` // Keyed by language, e.g. "ja"
+
+	PHash       string   `json:"phash,omitempty" firestore:"phash,omitempty"`             // Hex encoded dHash of the image
+	Aliases     []string `json:"aliases,omitempty" firestore:"aliases,omitempty"`         // IDs of near-duplicates merged into this image
+	DuplicateOf string   `json:"duplicateOf,omitempty" firestore:"duplicateOf,omitempty"` // ID of the near-duplicate flagged for review
+
+	BlurHash string `json:"blurHash,omitempty" firestore:"blurHash,omitempty"`
+	LQIP     string `json:"lqip,omitempty" firestore:"lqip,omitempty"` // Data URI of a tiny JPEG preview
+
 	Tags        map[string]float32 `json:"tags,omitempty" firestore:"tags,omitempty"`
 	TagsOrdered []string           `json:"tagsOrdered,omitempty" firestore:"tagsOrdered,omitempty"`
 	Colors      []string           `json:"colors,omitempty" firestore:"colors,omitempty"` // Hex strings like "#4A90D9"
+
+	Landmarks     []Landmark  `json:"landmarks,omitempty" firestore:"landmarks,omitempty"`
+	LandmarkSlugs []string    `json:"landmarkSlugs,omitempty" firestore:"landmarkSlugs,omitempty"` // For querying by landmark
+	WebEntities   []WebEntity `json:"webEntities,omitempty" firestore:"webEntities,omitempty"`
+	SafeSearch    *SafeSearch `json:"safeSearch,omitempty" firestore:"safeSearch,omitempty"`
+
+	Place   string  `json:"place,omitempty" firestore:"place,omitempty"` // Name of the landmark or place the location was found from
+	Lat     float64 `json:"lat,omitempty" firestore:"lat,omitempty"`
+	Lng     float64 `json:"lng,omitempty" firestore:"lng,omitempty"`
+	Geohash string  `json:"geohash,omitempty" firestore:"geohash,omitempty"` // For querying by distance
+	Country string  `json:"country,omitempty" firestore:"country,omitempty"` // ISO 3166-1 alpha-2 code, e.g. "US"
+
+	Photographer     string `json:"photographer,omitempty" firestore:"photographer,omitempty"` // Parsed from Copyright
+	PhotographerSlug string `json:"photographerSlug,omitempty" firestore:"photographerSlug,omitempty"`
+	Agency           string `json:"agency,omitempty" firestore:"agency,omitempty"`
+	AgencySlug       string `json:"agencySlug,omitempty" firestore:"agencySlug,omitempty"`
+
+	// Extra metadata from Bing, as published in Market
+	Headline      string    `json:"headline,omitempty" firestore:"headline,omitempty"`
+	CopyrightLink string    `json:"copyrightLink,omitempty" firestore:"copyrightLink,omitempty"` // Bing search about the image
+	Quiz          string    `json:"quiz,omitempty" firestore:"quiz,omitempty"`
+	Hsh           string    `json:"hsh,omitempty" firestore:"hsh,omitempty"` // Hash of the image content
+	FullStartDate string    `json:"fullStartDate,omitempty" firestore:"fullStartDate,omitempty"`
+	EndDate       int       `json:"endDate,omitempty" firestore:"endDate,omitempty"`
+	Hotspots      []Hotspot `json:"hotspots,omitempty" firestore:"hotspots,omitempty"`
+}
+
+// SetMetadata copies Bing's extra metadata from another image.
+func (i *Image) SetMetadata(from Image) {
+	i.Headline = from.Headline
+	i.CopyrightLink = from.CopyrightLink
+	i.Quiz = from.Quiz
+	i.Hsh = from.Hsh
+	i.FullStartDate = from.FullStartDate
+	i.EndDate = from.EndDate
+	i.Hotspots = from.Hotspots
 }
 
+// SetAttribution sets the photographer and agency from the copyright.
+func (i *Image) SetAttribution() {
+	i.Photographer, i.Agency = ParseAttribution(i.Copyright)
+	i.PhotographerSlug, i.AgencySlug = Slug(i.Photographer), Slug(i.Agency)
+}
+
+// SetOrientations records whether the image has landscape and portrait variants, from their resolutions.
+func (i *Image) SetOrientations() {
+	i.Landscape, i.Portrait = false, false
+	for _, res := range i.Resolutions {
+		w, h, ok := resolution.Size(res)
+		switch {
+		case !ok:
+		case h > w:
+			i.Portrait = true
+		default:
+			i.Landscape = true
+		}
+	}
+}
+
+// URL returns the 1920x1200 variant if it is known to exist, otherwise the 1920x1080 one.
 func (i Image) URL() string {
-	return i.URLBase + "_1920x1080.jpg"
+	if slices.Contains(i.Resolutions, "1920x1200") {
+		return i.URLFor("1920x1200")
+	}
+	return i.URLFor("1920x1080")
+}
+
+// URLFor returns the URL of the given resolution variant.
+func (i Image) URLFor(resolution string) string {
+	return i.URLBase + "_" + resolution + ".jpg"
 }
 
 func From(bw bing.Image, market string) (Image, error) {
@@ -54,6 +225,14 @@ func From(bw bing.Image, market string) (Image, error) {
 		return Image{}, err
 	}
 
+	// missing in older responses
+	endDate, _ := strconv.Atoi(bw.EndDate)
+
+	var hotspots []Hotspot
+	for _, h := range bw.Hotspots {
+		hotspots = append(hotspots, Hotspot{Desc: h.Desc, Link: absoluteURL(h.Link), Query: h.Query, LocX: h.LocX, LocY: h.LocY})
+	}
+
 	image := Image{
 		ID:        id,
 		Title:     title,
@@ -62,12 +241,60 @@ func From(bw bing.Image, market string) (Image, error) {
 		Market:    market,
 		URLBase:   urlBase,
 		FullDesc:  fullDesc,
-		Tags:      make(map[string]float32),
+		Markets:   []string{market},
+		Appearances: []Appearance{
+			{Market: market, Date: date, Title: title, Copyright: copyright, Headline: bw.Title},
+		},
+		Localized: map[string]Localization{
+			Language(market): {Title: title, Copyright: copyright, Headline: bw.Title},
+		},
+		Tags:          make(map[string]float32),
+		Headline:      bw.Title,
+		CopyrightLink: absoluteURL(bw.CopyrightLink),
+		Quiz:          absoluteURL(bw.Quiz),
+		Hsh:           bw.Hsh,
+		FullStartDate: bw.FullStartDate,
+		EndDate:       endDate,
+		Hotspots:      hotspots,
 	}
+	image.SetAttribution()
 
 	return image, nil
 }
 
+// absoluteURL prefixes links relative to Bing, like "/search?q=...", with its URL.
+func absoluteURL(link string) string {
+	if strings.HasPrefix(link, "/") {
+		return bingURL + link
+	}
+	return link
+}
+
+// Slug returns s in lowercase with runs of anything other than letters and digits replaced by a hyphen,
+// e.g. "washington-monument" for "Washington Monument".
+func Slug(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+// Language returns the language of a market, e.g. "pt" for "pt-BR".
+func Language(market string) string {
+	lang, _, _ := strings.Cut(market, "-")
+	return strings.ToLower(lang)
+}
+
 // parseCopyright extracts the title and copyright from Bing's copyright string.
 // Bing formats: "Title (© Attribution)" or "Title（© Attribution）" (Chinese)
 func parseCopyright(raw string) (title, copyright string, err error) {
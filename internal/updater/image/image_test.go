@@ -0,0 +1,21 @@
+package image_test
+
+import (
+	"testing"
+
+	"api/internal/updater/image"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImage_SetOrientations(t *testing.T) {
+	img := image.Image{Resolutions: []string{"UHD", "1920x1080"}, Portrait: true}
+	img.SetOrientations()
+	assert.True(t, img.Landscape)
+	assert.False(t, img.Portrait)
+
+	img.Resolutions = []string{"1080x1920", "768x1280"}
+	img.SetOrientations()
+	assert.False(t, img.Landscape)
+	assert.True(t, img.Portrait)
+}
@@ -0,0 +1,123 @@
+package updater
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"api/internal/collections"
+	"api/internal/updater/firestore"
+	"api/internal/updater/message"
+)
+
+// What started a job.
+const (
+	TriggerPubSub   = "pubsub"
+	TriggerPush     = "push"
+	TriggerSchedule = "schedule"
+	TriggerCLI      = "cli"
+)
+
+const defaultJobRetention = 30 * 24 * time.Hour
+
+// Result is what an operation did, recorded in its job.
+type Result struct {
+	Markets map[string]int // Wallpapers Bing listed per market
+	New     []string
+	Updated []string
+	Failed  []firestore.JobFailure
+}
+
+// fail records an image that couldn't be stored.
+func (r *Result) fail(id string, err error) {
+	log.Printf("warning: %s: %v", id, err)
+	r.Failed = append(r.Failed, firestore.JobFailure{ID: id, Error: err.Error()})
+}
+
+// operation performs what a message asks for, recording what it did in res.
+type operation func(ctx context.Context, m message.Message, res *Result) error
+
+// Handlers returns the registry dispatching update messages to the updater,
+// recording each run as a job started by trigger. Runs don't overlap, see locked.
+func (u *Updater) Handlers(trigger string) *message.Registry {
+	r := message.NewRegistry()
+	for typ := range u.operations() {
+		r.Handle(typ, func(ctx context.Context, m message.Message) error {
+			return u.locked(ctx, trigger, m)
+		})
+	}
+	return r
+}
+
+// runJob performs an operation, saving a job record when it starts and ends and deleting expired ones.
+// Failing to save the record doesn't stop the operation.
+func (u *Updater) runJob(ctx context.Context, trigger string, m message.Message, op operation) error {
+	job := firestore.Job{
+		ID:        newJobID(time.Now()),
+		Type:      m.Type,
+		Trigger:   trigger,
+		Status:    firestore.JobRunning,
+		StartedAt: time.Now(),
+	}
+	if err := u.firestoreClient.SetJob(ctx, collections.Jobs, job); err != nil {
+		log.Printf("warning: failed to save job %s: %v", job.ID, err)
+	}
+
+	res := &Result{Markets: make(map[string]int)}
+	err := op(ctx, m, res)
+
+	ended := time.Now()
+	job.EndedAt = &ended
+	job.Markets, job.New, job.Updated, job.Failed = res.Markets, res.New, res.Updated, res.Failed
+	switch {
+	case err != nil:
+		job.Status, job.Error = firestore.JobFailed, err.Error()
+	case len(res.Failed) > 0:
+		job.Status = firestore.JobPartial
+	default:
+		job.Status = firestore.JobSucceeded
+	}
+
+	// the record is saved even if the run was cancelled
+	saveCtx := context.WithoutCancel(ctx)
+	if err := u.firestoreClient.SetJob(saveCtx, collections.Jobs, job); err != nil {
+		log.Printf("warning: failed to save job %s: %v", job.ID, err)
+	}
+	if u.jobRetention > 0 {
+		if _, err := u.firestoreClient.DeleteJobsBefore(saveCtx, collections.Jobs, ended.Add(-u.jobRetention)); err != nil {
+			log.Printf("warning: failed to delete expired jobs: %v", err)
+		}
+	}
+
+	fmt.Printf("job %s %s\n", job.ID, job.Status)
+	return err
+}
+
+// newJobID returns an ID that sorts by start time.
+func newJobID(start time.Time) string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return start.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(b)
+}
+
+// newJobRetention reads how long job records are kept from JOB_RETENTION, e.g. "720h". Zero keeps them forever.
+func newJobRetention() (time.Duration, error) {
+	v := os.Getenv("JOB_RETENTION")
+	if v == "" {
+		return defaultJobRetention, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid job retention %q: %w", v, err)
+	}
+	if d < 0 {
+		return 0, errors.New("job retention can't be negative")
+	}
+	return d, nil
+}
@@ -0,0 +1,143 @@
+package jpeginfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Info is read from the header of a JPEG, without decoding the image.
+type Info struct {
+	Width   int
+	Height  int
+	Quality int // Estimated IJG quality (1-100), zero if the file has no luminance table
+}
+
+// ErrTruncated is returned when b ends before the frame header.
+var ErrTruncated = errors.New("jpeg header is truncated")
+
+// stdLuminance is the IJG luminance quantization table at quality 50, in zig-zag order.
+var stdLuminance = [64]int{
+	16, 11, 12, 14, 12, 10, 16, 14,
+	13, 14, 18, 17, 16, 19, 24, 40,
+	26, 24, 22, 22, 24, 49, 35, 37,
+	29, 40, 58, 51, 61, 60, 57, 51,
+	56, 55, 64, 72, 92, 78, 64, 68,
+	87, 69, 55, 56, 80, 109, 81, 87,
+	95, 98, 103, 104, 103, 62, 77, 113,
+	121, 112, 100, 120, 92, 101, 103, 99,
+}
+
+// Parse reads the size and quality from the start of a JPEG, which only needs to include the frame header.
+func Parse(b []byte) (Info, error) {
+	if len(b) < 2 || b[0] != 0xFF || b[1] != 0xD8 {
+		return Info{}, errors.New("not a jpeg")
+	}
+
+	var info Info
+	for i := 2; ; {
+		// skip fill bytes before the marker
+		for i < len(b) && b[i] == 0xFF && i+1 < len(b) && b[i+1] == 0xFF {
+			i++
+		}
+		if i+4 > len(b) {
+			return Info{}, ErrTruncated
+		}
+		if b[i] != 0xFF {
+			return Info{}, errors.New("invalid jpeg marker")
+		}
+
+		marker := b[i+1]
+		length := int(binary.BigEndian.Uint16(b[i+2:]))
+		if length < 2 {
+			return Info{}, errors.New("invalid jpeg segment length")
+		}
+		end := i + 2 + length
+		if end > len(b) {
+			return Info{}, ErrTruncated
+		}
+		segment := b[i+4 : end]
+
+		switch {
+		case marker == 0xDB:
+			if q, ok := luminanceQuality(segment); ok {
+				info.Quality = q
+			}
+		case marker >= 0xC0 && marker <= 0xCF && marker != 0xC4 && marker != 0xC8 && marker != 0xCC:
+			// start of frame: precision, height, width
+			if len(segment) < 5 {
+				return Info{}, errors.New("invalid jpeg frame header")
+			}
+			info.Height = int(binary.BigEndian.Uint16(segment[1:]))
+			info.Width = int(binary.BigEndian.Uint16(segment[3:]))
+			return info, nil
+		case marker == 0xDA:
+			return Info{}, errors.New("jpeg has no frame header before its scan")
+		}
+		i = end
+	}
+}
+
+// luminanceQuality estimates the quality the luminance table in a DQT segment was scaled for,
+// inverting the IJG scaling of stdLuminance.
+func luminanceQuality(segment []byte) (int, bool) {
+	for len(segment) > 0 {
+		precision, id := segment[0]>>4, segment[0]&0x0F
+		size := 64
+		if precision == 1 {
+			size = 128
+		}
+		if len(segment) < 1+size {
+			return 0, false
+		}
+
+		table := segment[1 : 1+size]
+		segment = segment[1+size:]
+		if id != 0 {
+			continue
+		}
+
+		// values clamped to the 8-bit range don't tell how they were scaled
+		var sum float64
+		n, ones := 0, 0
+		for i := range 64 {
+			v := int(table[i])
+			if precision == 1 {
+				v = int(binary.BigEndian.Uint16(table[2*i:]))
+			}
+			if v == 1 {
+				ones++
+				continue
+			}
+			if v == 255 {
+				continue
+			}
+			sum += float64(v) * 100 / float64(stdLuminance[i])
+			n++
+		}
+		switch {
+		case n == 0 && ones > 0:
+			return 100, true
+		case n == 0:
+			return 1, true
+		}
+
+		scale := sum / float64(n)
+		var q float64
+		if scale <= 100 {
+			q = (200 - scale) / 2
+		} else {
+			q = 5000 / scale
+		}
+		return int(max(1, min(100, math.Round(q)))), true
+	}
+	return 0, false
+}
+
+// AspectRatio returns width divided by height, rounded to three decimals.
+func (i Info) AspectRatio() float64 {
+	if i.Height == 0 {
+		return 0
+	}
+	return math.Round(float64(i.Width)/float64(i.Height)*1000) / 1000
+}
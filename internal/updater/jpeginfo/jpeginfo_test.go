@@ -0,0 +1,45 @@
+package jpeginfo_test
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"api/internal/updater/jpeginfo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, w, h, quality int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), &jpeg.Options{Quality: quality}))
+	return buf.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	for _, quality := range []int{10, 50, 75, 85, 95, 100} {
+		info, err := jpeginfo.Parse(encode(t, 192, 120, quality))
+		require.NoError(t, err, quality)
+		assert.Equal(t, jpeginfo.Info{Width: 192, Height: 120, Quality: quality}, info, quality)
+	}
+}
+
+func TestParse_HeaderOnly(t *testing.T) {
+	b := encode(t, 1080, 1920, 85)
+
+	// the header is all that is fetched
+	info, err := jpeginfo.Parse(b[:700])
+	require.NoError(t, err)
+	assert.Equal(t, 1080, info.Width)
+	assert.Equal(t, 1920, info.Height)
+	assert.Equal(t, 0.563, info.AspectRatio())
+
+	_, err = jpeginfo.Parse(b[:100])
+	assert.ErrorIs(t, err, jpeginfo.ErrTruncated)
+
+	_, err = jpeginfo.Parse([]byte("<html>"))
+	assert.Error(t, err)
+}
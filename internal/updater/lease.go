@@ -0,0 +1,119 @@
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"api/internal/updater/firestore"
+	"api/internal/updater/lock"
+	"api/internal/updater/message"
+	"api/internal/updater/translate"
+
+	gfirestore "cloud.google.com/go/firestore"
+)
+
+const (
+	leaseCollection = "Leases"
+	updateLease     = "update"
+
+	defaultLeaseTTL = 2 * time.Minute
+)
+
+// ErrBusy is returned when another run holds the update lease. The run can be retried later.
+// It wraps message.ErrBusy, so that the transports redeliver the message without counting a failed attempt.
+var ErrBusy = fmt.Errorf("%w: another update is running", message.ErrBusy)
+
+// newLocker configures the lease that keeps runs from overlapping from LOCK: "firestore" (the default)
+// shares it between instances, "memory" only within this process, and "off" disables it.
+func newLocker(fs *gfirestore.Client) (lock.Locker, error) {
+	switch v := os.Getenv("LOCK"); v {
+	case "", "firestore":
+		return lock.NewFirestore(fs, leaseCollection, lock.Holder()), nil
+	case "memory":
+		return lock.NewMemory(), nil
+	case "off":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid lock %q", v)
+	}
+}
+
+// newLeaseTTL reads how long a lease is held without being renewed from LEASE_TTL. Runs renew it every third of that.
+func newLeaseTTL() (time.Duration, error) {
+	v := os.Getenv("LEASE_TTL")
+	if v == "" {
+		return defaultLeaseTTL, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lease ttl %q: %w", v, err)
+	}
+	if d <= 0 {
+		return 0, errors.New("lease ttl must be positive")
+	}
+	return d, nil
+}
+
+// Lock returns the locker keeping runs from overlapping, nil if disabled, and how long its leases last.
+func (u *Updater) Lock() (lock.Locker, time.Duration) {
+	return u.locker, u.leaseTTL
+}
+
+// Locked runs fn while holding the update lease, with an updater whose writes fail with lock.ErrLost
+// once another run has taken the lease over. It returns ErrBusy if another run holds the lease.
+func (u *Updater) Locked(ctx context.Context, fn func(ctx context.Context, u *Updater) error) error {
+	if u.locker == nil {
+		return fn(ctx, u)
+	}
+
+	ran, err := lock.Hold(ctx, u.locker, updateLease, "", u.leaseTTL, func(ctx context.Context, h lock.Handle) error {
+		return fn(ctx, u.fenced(h))
+	})
+	if !ran && err == nil {
+		return ErrBusy
+	}
+	return err
+}
+
+// locked performs the operation a message asks for as a job, see Locked.
+func (u *Updater) locked(ctx context.Context, trigger string, m message.Message) error {
+	return u.Locked(ctx, func(ctx context.Context, fenced *Updater) error {
+		// the job is recorded by u, so that it is saved even if the lease is lost
+		return u.runJob(ctx, trigger, m, fenced.operations()[m.Type])
+	})
+}
+
+// fenced returns a copy of the updater whose writes, including cached translations, check that the lease h is still held.
+// Only Firestore writes can be fenced.
+func (u *Updater) fenced(h lock.Handle) *Updater {
+	fenced := *u
+	c, ok := u.firestoreClient.(*firestore.Client)
+	if !ok {
+		return &fenced
+	}
+
+	var fc *firestore.Client
+	if f, ok := u.locker.(*lock.Firestore); ok {
+		// check the lease in the same transaction as the write where it is stored in Firestore too
+		fc = c.Fenced(func(ctx context.Context, fn func(tx *gfirestore.Transaction) error) error {
+			return f.Fenced(ctx, h, fn)
+		})
+	} else {
+		fc = c.Fenced(c.CheckedFence(func(ctx context.Context) error {
+			return u.locker.Check(ctx, h)
+		}))
+	}
+	fenced.firestoreClient = fc
+
+	// translations cached in Firestore are written during the run too
+	if t, ok := u.translator.(*translate.Cached); ok {
+		if _, ok := t.Cache.(*firestore.TranslationCache); ok {
+			fenced.translator = &translate.Cached{Translator: t.Translator, Cache: fc.TranslationCache(translationsCollection)}
+		}
+	}
+	return &fenced
+}
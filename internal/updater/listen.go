@@ -0,0 +1,179 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"api/internal/updater/lock"
+	"api/internal/updater/schedule"
+	"api/internal/updater/trigger"
+
+	gfirestore "cloud.google.com/go/firestore"
+)
+
+const (
+	// Bing publishes each market's wallpaper at its midnight, so check every hour
+	defaultSchedule = "0 * * * *"
+	scheduleLease   = "schedule"
+	defaultPushPort = "8081"
+	defaultPushPath = "/push"
+
+	// failed messages are retried with backoff a few times, rather than forever
+	defaultMaxAttempts = 5
+	defaultMinBackoff  = 10 * time.Second
+	defaultMaxBackoff  = 10 * time.Minute
+)
+
+// Listen runs updates whenever they are triggered until ctx is done: the operation requested by every message
+// published to the updater topic, or pushed to the updater when TRIGGER is "push",
+// or a full update at the times in SCHEDULE when TRIGGER is "schedule".
+func Listen(ctx context.Context, fs *gfirestore.Client) error {
+	kind := os.Getenv("TRIGGER")
+	if kind != "" && kind != "pubsub" && kind != "push" && kind != "schedule" {
+		return fmt.Errorf("invalid trigger %q", kind)
+	}
+
+	u, err := New(ctx, fs)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "schedule":
+		locker, ttl := u.Lock()
+		s, err := newScheduler(locker, ttl, func(ctx context.Context) error {
+			return u.Handlers(TriggerSchedule).Dispatch(ctx, nil)
+		})
+		if err != nil {
+			return err
+		}
+		return s.Start(ctx)
+	case "push":
+		return newPush().Start(ctx, u.Handlers(TriggerPush).Dispatch)
+	default:
+		t, err := newPubSub()
+		if err != nil {
+			return err
+		}
+		return t.Start(ctx, u.Handlers(TriggerPubSub).Dispatch)
+	}
+}
+
+// newPubSub configures the updater subscription to retry failed messages after PUBSUB_MIN_BACKOFF, up to
+// PUBSUB_MAX_BACKOFF, at most PUBSUB_MAX_ATTEMPTS times before moving them to PUBSUB_DEAD_LETTER_TOPIC if set,
+// handling PUBSUB_CONCURRENCY messages at once.
+func newPubSub() (*trigger.PubSub, error) {
+	t := &trigger.PubSub{
+		ProjectID:         os.Getenv("PROJECT_ID"),
+		TopicID:           TopicID,
+		SubID:             SubID,
+		MaxAttempts:       defaultMaxAttempts,
+		DeadLetterTopicID: os.Getenv("PUBSUB_DEAD_LETTER_TOPIC"),
+		MinBackoff:        defaultMinBackoff,
+		MaxBackoff:        defaultMaxBackoff,
+		// runs never overlap, so further messages would only be retried later
+		Concurrency: 1,
+	}
+
+	ints := []struct {
+		env  string
+		name string
+		v    *int
+	}{
+		{"PUBSUB_MAX_ATTEMPTS", "max attempts", &t.MaxAttempts},
+		{"PUBSUB_CONCURRENCY", "concurrency", &t.Concurrency},
+		{"PUBSUB_STREAMS", "streams", &t.Streams},
+	}
+	for _, i := range ints {
+		if v := os.Getenv(i.env); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid pubsub %s %q", i.name, v)
+			}
+			*i.v = n
+		}
+	}
+
+	durations := []struct {
+		env  string
+		name string
+		v    *time.Duration
+	}{
+		{"PUBSUB_MIN_BACKOFF", "min backoff", &t.MinBackoff},
+		{"PUBSUB_MAX_BACKOFF", "max backoff", &t.MaxBackoff},
+	}
+	for _, d := range durations {
+		if v := os.Getenv(d.env); v != "" {
+			n, err := time.ParseDuration(v)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid pubsub %s %q", d.name, v)
+			}
+			*d.v = n
+		}
+	}
+
+	return t, nil
+}
+
+// newPush configures the endpoint of a Pub/Sub push subscription on PUSH_PORT at PUSH_PATH,
+// accepting tokens for PUSH_AUDIENCE issued to PUSH_SERVICE_ACCOUNT.
+func newPush() *trigger.Push {
+	port := os.Getenv("PUSH_PORT")
+	if port == "" {
+		port = defaultPushPort
+	}
+	path := os.Getenv("PUSH_PATH")
+	if path == "" {
+		path = defaultPushPath
+	}
+
+	return &trigger.Push{
+		Addr:     ":" + port,
+		Path:     path,
+		Audience: os.Getenv("PUSH_AUDIENCE"),
+		Email:    os.Getenv("PUSH_SERVICE_ACCOUNT"),
+	}
+}
+
+// newScheduler configures updates at the times of the cron expression SCHEDULE in SCHEDULE_TZ,
+// each delayed by a random SCHEDULE_JITTER, with a lease so that only one instance runs each of them.
+func newScheduler(locker lock.Locker, ttl time.Duration, run func(ctx context.Context) error) (*schedule.Scheduler, error) {
+	expr := os.Getenv("SCHEDULE")
+	if expr == "" {
+		expr = defaultSchedule
+	}
+	sched, err := schedule.Parse(expr, os.Getenv("SCHEDULE_TZ"))
+	if err != nil {
+		return nil, err
+	}
+
+	var jitter time.Duration
+	if v := os.Getenv("SCHEDULE_JITTER"); v != "" {
+		jitter, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule jitter %q: %w", v, err)
+		}
+	}
+
+	return &schedule.Scheduler{
+		Schedule: sched,
+		Run:      run,
+		Jitter:   jitter,
+		Lock:     locker,
+		LockName: scheduleLease,
+		LockTTL:  ttl,
+	}, nil
+}
+
+// Update runs a single update.
+func Update(ctx context.Context, fs *gfirestore.Client) error {
+	u, err := New(ctx, fs)
+	if err != nil {
+		return err
+	}
+
+	return u.Handlers(TriggerCLI).Dispatch(ctx, nil)
+}
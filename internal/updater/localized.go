@@ -0,0 +1,50 @@
+package updater
+
+import (
+	"maps"
+	"slices"
+
+	imgpkg "api/internal/updater/image"
+)
+
+// localizedHistory returns the localized titles of a stored image.
+// Documents stored before localized titles were recorded are seeded with their English title,
+// which was machine translated if the image came from a non-English market.
+func localizedHistory(image imgpkg.Image) map[string]imgpkg.Localization {
+	if len(image.Localized) > 0 || image.Title == "" {
+		return image.Localized
+	}
+
+	return map[string]imgpkg.Localization{
+		"en": {
+			Title:             image.Title,
+			Copyright:         image.Copyright,
+			MachineTranslated: !slices.Contains(ENMarkets, image.Market),
+		},
+	}
+}
+
+// mergeLocalized adds the languages in b that are missing from a.
+// Original titles replace machine translations, otherwise the existing title is kept
+// and only a missing headline is filled in.
+func mergeLocalized(a, b map[string]imgpkg.Localization) map[string]imgpkg.Localization {
+	if len(a) == 0 && len(b) == 0 {
+		return a
+	}
+
+	out := maps.Clone(a)
+	if out == nil {
+		out = make(map[string]imgpkg.Localization, len(b))
+	}
+	for lang, v := range b {
+		cur, ok := out[lang]
+		switch {
+		case !ok || (cur.MachineTranslated && !v.MachineTranslated):
+			out[lang] = v
+		case cur.Headline == "" && v.Headline != "" && cur.MachineTranslated == v.MachineTranslated:
+			cur.Headline = v.Headline
+			out[lang] = cur
+		}
+	}
+	return out
+}
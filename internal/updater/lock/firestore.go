@@ -0,0 +1,124 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Firestore keeps leases in a Firestore collection, one document per name,
+// so that only one of several instances holds one at a time.
+type Firestore struct {
+	client     *firestore.Client
+	collection string
+	holder     string
+}
+
+// NewFirestore returns leases stored in collection, acquired on behalf of holder.
+func NewFirestore(client *firestore.Client, collection, holder string) *Firestore {
+	return &Firestore{client: client, collection: collection, holder: holder}
+}
+
+func (f *Firestore) Acquire(ctx context.Context, name, key string, ttl time.Duration) (Handle, bool, error) {
+	var h Handle
+	acquired := false
+	err := f.update(ctx, name, func(l Lease) (*Lease, error) {
+		next, ok := l.acquire(f.holder, key, time.Now(), ttl)
+		if !ok {
+			acquired = false
+			return nil, nil
+		}
+		h, acquired = Handle{Name: name, Token: next.Token}, true
+		return &next, nil
+	})
+	if err != nil {
+		return Handle{}, false, fmt.Errorf("failed to acquire lease %q: %w", name, err)
+	}
+	return h, acquired, nil
+}
+
+func (f *Firestore) Renew(ctx context.Context, h Handle, ttl time.Duration) error {
+	err := f.update(ctx, h.Name, func(l Lease) (*Lease, error) {
+		if !l.heldBy(f.holder, h) {
+			return nil, ErrLost
+		}
+		l.Expires = time.Now().Add(ttl)
+		return &l, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to renew lease %q: %w", h.Name, err)
+	}
+	return nil
+}
+
+func (f *Firestore) Release(ctx context.Context, h Handle) error {
+	err := f.update(ctx, h.Name, func(l Lease) (*Lease, error) {
+		if !l.heldBy(f.holder, h) {
+			return nil, nil
+		}
+		l.Expires = time.Now()
+		return &l, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release lease %q: %w", h.Name, err)
+	}
+	return nil
+}
+
+func (f *Firestore) Check(ctx context.Context, h Handle) error {
+	return f.Fenced(ctx, h, func(*firestore.Transaction) error { return nil })
+}
+
+// Fenced runs fn in a transaction that fails with ErrLost unless h still holds its lease,
+// so that the writes fn makes are never applied after another holder took the lease over.
+func (f *Firestore) Fenced(ctx context.Context, h Handle, fn func(tx *firestore.Transaction) error) error {
+	ref := f.client.Collection(f.collection).Doc(h.Name)
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		l, err := getLease(tx, ref)
+		if err != nil {
+			return err
+		}
+		if !l.heldBy(f.holder, h) {
+			return fmt.Errorf("%w: %q", ErrLost, h.Name)
+		}
+		return fn(tx)
+	})
+}
+
+// update replaces the named lease in a transaction with the one fn returns, unless it is nil.
+func (f *Firestore) update(ctx context.Context, name string, fn func(l Lease) (*Lease, error)) error {
+	ref := f.client.Collection(f.collection).Doc(name)
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		l, err := getLease(tx, ref)
+		if err != nil {
+			return err
+		}
+
+		next, err := fn(l)
+		if err != nil || next == nil {
+			return err
+		}
+		return tx.Set(ref, *next)
+	})
+}
+
+// getLease reads a lease in a transaction, returning a zero lease if it doesn't exist yet.
+func getLease(tx *firestore.Transaction, ref *firestore.DocumentRef) (Lease, error) {
+	dsnap, err := tx.Get(ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return Lease{}, nil
+		}
+		return Lease{}, err
+	}
+
+	var l Lease
+	if err := dsnap.DataTo(&l); err != nil {
+		return Lease{}, err
+	}
+	return l, nil
+}
@@ -0,0 +1,131 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// ErrLost is returned when a lease is no longer held, because another holder took it over after it expired.
+var ErrLost = errors.New("lease lost")
+
+// Lease is the stored state of a named lease.
+type Lease struct {
+	Holder   string    `firestore:"holder"`
+	Key      string    `firestore:"key,omitempty"` // What the lease was last acquired for, e.g. a scheduled time
+	Token    int64     `firestore:"token"`         // Fencing token, incremented on every acquisition
+	Acquired time.Time `firestore:"acquired"`
+	Expires  time.Time `firestore:"expires"`
+}
+
+// Free reports whether the lease can be acquired at now for key:
+// it has expired, and wasn't already acquired for the same key.
+func (l Lease) Free(now time.Time, key string) bool {
+	if key != "" && l.Key == key {
+		return false
+	}
+	return !now.Before(l.Expires)
+}
+
+// acquire returns the lease taken over by holder, or false if it isn't free.
+func (l Lease) acquire(holder, key string, now time.Time, ttl time.Duration) (Lease, bool) {
+	if !l.Free(now, key) {
+		return l, false
+	}
+	return Lease{Holder: holder, Key: key, Token: l.Token + 1, Acquired: now, Expires: now.Add(ttl)}, true
+}
+
+// heldBy reports whether h is still the latest acquisition of the lease by holder.
+// A lease that expired but wasn't taken over is still held.
+func (l Lease) heldBy(holder string, h Handle) bool {
+	return l.Holder == holder && l.Token == h.Token
+}
+
+// Handle is a lease held by this process. Writes can be fenced with its token,
+// so that they aren't applied once another holder has taken the lease over.
+type Handle struct {
+	Name  string
+	Token int64
+}
+
+// Locker hands out leases shared by every process using it.
+type Locker interface {
+	// Acquire takes the named lease for ttl, reporting false if another holder has it
+	// or it was already acquired for key. An empty key can be acquired repeatedly.
+	Acquire(ctx context.Context, name, key string, ttl time.Duration) (Handle, bool, error)
+	// Renew extends a held lease to expire ttl from now, or returns ErrLost.
+	Renew(ctx context.Context, h Handle, ttl time.Duration) error
+	// Release lets a held lease expire now, keeping its key. It does nothing if the lease was lost.
+	Release(ctx context.Context, h Handle) error
+	// Check returns ErrLost unless the lease is still held.
+	Check(ctx context.Context, h Handle) error
+}
+
+// Hold runs fn while holding the named lease, renewing it every third of ttl, and releases it afterwards.
+// It reports false without running fn if the lease isn't free. If the lease is lost, fn's context is cancelled
+// and the error wraps ErrLost.
+func Hold(ctx context.Context, l Locker, name, key string, ttl time.Duration, fn func(ctx context.Context, h Handle) error) (bool, error) {
+	h, ok, err := l.Acquire(ctx, name, key, ttl)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			err := l.Renew(runCtx, h, ttl)
+			if errors.Is(err, ErrLost) {
+				cancel(err)
+				return
+			}
+			if err != nil {
+				// the lease is still held until it expires, so try again next time
+				log.Printf("warning: failed to renew lease %q: %v", name, err)
+			}
+		}
+	}()
+
+	err = fn(runCtx, h)
+	close(done)
+	<-renewed
+
+	if cause := context.Cause(runCtx); errors.Is(cause, ErrLost) {
+		return true, fmt.Errorf("lease %q lost during the run: %w", name, errors.Join(cause, err))
+	}
+
+	// release with a fresh context, so that the lease doesn't stay held after shutdown
+	if err := l.Release(context.WithoutCancel(ctx), h); err != nil {
+		log.Printf("warning: %v", err)
+	}
+	return true, err
+}
+
+// Holder returns a name for this process that is unique across instances.
+func Holder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
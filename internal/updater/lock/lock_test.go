@@ -0,0 +1,124 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLease_Free(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, Lease{}.Free(now, "2025-03-01T12:00:00Z"))
+
+	held := Lease{Holder: "a", Key: "2025-03-01T11:00:00Z", Expires: now.Add(time.Minute)}
+	assert.False(t, held.Free(now, "2025-03-01T12:00:00Z"))
+	assert.False(t, held.Free(now, ""))
+
+	expired := Lease{Holder: "a", Key: "2025-03-01T11:00:00Z", Expires: now}
+	assert.True(t, expired.Free(now, "2025-03-01T12:00:00Z"))
+	assert.True(t, expired.Free(now, ""))
+	// another instance already ran this scheduled time
+	assert.False(t, expired.Free(now, "2025-03-01T11:00:00Z"))
+}
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	a, ok, err := m.Acquire(ctx, "update", "", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, Handle{Name: "update", Token: 1}, a)
+
+	_, ok, err = m.Acquire(ctx, "update", "", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// renewing keeps it held past its first expiry
+	now = now.Add(50 * time.Second)
+	require.NoError(t, m.Renew(ctx, a, time.Minute))
+	now = now.Add(50 * time.Second)
+	_, ok, _ = m.Acquire(ctx, "update", "", time.Minute)
+	assert.False(t, ok)
+
+	// an expired lease is still held until it is taken over
+	now = now.Add(time.Minute)
+	require.NoError(t, m.Check(ctx, a))
+
+	b, ok, err := m.Acquire(ctx, "update", "", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, int64(2), b.Token)
+
+	assert.ErrorIs(t, m.Check(ctx, a), ErrLost)
+	assert.ErrorIs(t, m.Renew(ctx, a, time.Minute), ErrLost)
+	// releasing a lost lease doesn't release the new holder's
+	require.NoError(t, m.Release(ctx, a))
+	require.NoError(t, m.Check(ctx, b))
+	_, ok, _ = m.Acquire(ctx, "update", "", time.Minute)
+	assert.False(t, ok)
+
+	require.NoError(t, m.Release(ctx, b))
+	_, ok, _ = m.Acquire(ctx, "update", "", time.Minute)
+	assert.True(t, ok)
+}
+
+func TestHold(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	ran, err := Hold(ctx, m, "update", "", time.Minute, func(ctx context.Context, h Handle) error {
+		assert.Equal(t, int64(1), h.Token)
+
+		// held for as long as fn runs
+		_, ok, err := m.Acquire(ctx, "update", "", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		return errors.New("failed")
+	})
+	assert.True(t, ran)
+	assert.EqualError(t, err, "failed")
+
+	// released afterwards
+	_, ok, err := m.Acquire(ctx, "update", "", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ran, err = Hold(ctx, m, "update", "", time.Minute, func(context.Context, Handle) error {
+		t.Fatal("ran while the lease was held")
+		return nil
+	})
+	assert.False(t, ran)
+	assert.NoError(t, err)
+}
+
+func TestHold_Lost(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	ran, err := Hold(ctx, m, "update", "", 30*time.Millisecond, func(ctx context.Context, h Handle) error {
+		// another instance takes over after the lease expired
+		m.mu.Lock()
+		m.leases["update"] = Lease{Holder: "other", Token: h.Token + 1, Expires: now.Add(time.Hour)}
+		m.mu.Unlock()
+
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.True(t, ran)
+	assert.ErrorIs(t, err, ErrLost)
+	assert.Equal(t, "other", m.leases["update"].Holder)
+}
+
+func TestHolder(t *testing.T) {
+	assert.NotEqual(t, Holder(), Holder())
+}
@@ -0,0 +1,70 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Memory keeps leases in memory, for a single instance or tests.
+type Memory struct {
+	mu     sync.Mutex
+	leases map[string]Lease
+
+	now func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{leases: make(map[string]Lease), now: time.Now}
+}
+
+// memoryHolder is the holder of every in-memory lease, since they are only shared within the process.
+const memoryHolder = "memory"
+
+func (m *Memory) Acquire(_ context.Context, name, key string, ttl time.Duration) (Handle, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next, ok := m.leases[name].acquire(memoryHolder, key, m.now(), ttl)
+	if !ok {
+		return Handle{}, false, nil
+	}
+	m.leases[name] = next
+	return Handle{Name: name, Token: next.Token}, true, nil
+}
+
+func (m *Memory) Renew(_ context.Context, h Handle, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l := m.leases[h.Name]
+	if !l.heldBy(memoryHolder, h) {
+		return fmt.Errorf("%w: %q", ErrLost, h.Name)
+	}
+	l.Expires = m.now().Add(ttl)
+	m.leases[h.Name] = l
+	return nil
+}
+
+func (m *Memory) Release(_ context.Context, h Handle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l := m.leases[h.Name]
+	if l.heldBy(memoryHolder, h) {
+		l.Expires = m.now()
+		m.leases[h.Name] = l
+	}
+	return nil
+}
+
+func (m *Memory) Check(_ context.Context, h Handle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.leases[h.Name].heldBy(memoryHolder, h) {
+		return fmt.Errorf("%w: %q", ErrLost, h.Name)
+	}
+	return nil
+}
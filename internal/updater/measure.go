@@ -0,0 +1,92 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"api/internal/updater/firestore"
+	imgpkg "api/internal/updater/image"
+)
+
+// measure records the dimensions, byte size and JPEG quality of the resolution variants of an image
+// that haven't been measured yet, reporting whether any were. Failed variants are logged and skipped.
+func (u *Updater) measure(ctx context.Context, image *imgpkg.Image) (bool, error) {
+	changed := false
+	for _, res := range image.Resolutions {
+		if _, ok := image.Variants[res]; ok {
+			continue
+		}
+
+		info, size, err := u.prober.Measure(ctx, image.URLFor(res))
+		if err != nil {
+			if ctx.Err() != nil {
+				return changed, ctx.Err()
+			}
+			log.Printf("warning: failed to measure %s %s: %v", image.ID, res, err)
+			continue
+		}
+
+		if image.Variants == nil {
+			image.Variants = make(map[string]imgpkg.Variant)
+		}
+		image.Variants[res] = imgpkg.Variant{
+			Width:       info.Width,
+			Height:      info.Height,
+			AspectRatio: info.AspectRatio(),
+			Size:        size,
+			Quality:     info.Quality,
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// MeasureAll measures the variants of every stored image that haven't been measured yet and records
+// their orientations, probing those of images stored before variants were recorded.
+// It returns the IDs of the images that changed.
+func (u *Updater) MeasureAll(ctx context.Context) ([]string, error) {
+	updated := []string{}
+
+	q := firestore.ListQuery{Limit: reannotatePageSize}
+	for {
+		images, err := u.firestoreClient.List(ctx, q)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, image := range images {
+			if err := u.probeStored(ctx, &image); err != nil {
+				if ctx.Err() != nil {
+					return updated, ctx.Err()
+				}
+				log.Printf("warning: %v", err)
+				continue
+			}
+
+			landscape, portrait := image.Landscape, image.Portrait
+			image.SetOrientations()
+
+			changed, err := u.measure(ctx, &image)
+			if err != nil {
+				return updated, err
+			}
+			if !changed && image.Landscape == landscape && image.Portrait == portrait {
+				continue
+			}
+			if err := u.firestoreClient.UpdateVariants(ctx, image); err != nil {
+				return updated, err
+			}
+			updated = append(updated, image.ID)
+		}
+
+		if len(images) < q.Limit {
+			break
+		}
+		last := images[len(images)-1]
+		q.StartAfterDate, q.StartAfterID = last.Date, last.ID
+	}
+
+	fmt.Printf("%d images measured\n", len(updated))
+	return updated, nil
+}
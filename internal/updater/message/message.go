@@ -0,0 +1,133 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Version is the only message schema version understood.
+const Version = 1
+
+// Message types.
+const (
+	// TypeUpdate fetches and stores the latest wallpapers of Markets, or all markets if empty.
+	TypeUpdate = "update"
+	// TypeBackfill fetches and stores the wallpapers of Markets dated From to To, as far back as Bing keeps them.
+	TypeBackfill = "backfill"
+	// TypeReannotate annotates the stored wallpapers IDs again.
+	TypeReannotate = "reannotate"
+	// TypeRefresh downloads, annotates, locates, measures and mirrors the stored wallpaper ID again.
+	TypeRefresh = "refresh"
+)
+
+// ErrInvalid is wrapped by errors about messages that can never be handled, so there is no point retrying them.
+var ErrInvalid = errors.New("invalid message")
+
+// ErrBusy is wrapped by errors about messages that can't be handled until other work finishes,
+// so retrying them later doesn't count as a failed attempt.
+var ErrBusy = errors.New("busy")
+
+// Message asks the updater to perform an operation, e.g. {"version":1,"type":"refresh","id":"PresDayDC"}.
+type Message struct {
+	Version int      `json:"version"`
+	Type    string   `json:"type"`
+	Markets []string `json:"markets,omitempty"` // update, backfill
+	From    int      `json:"from,omitempty"`    // backfill, inclusive, e.g. 20250101
+	To      int      `json:"to,omitempty"`      // backfill, inclusive
+	IDs     []string `json:"ids,omitempty"`     // reannotate
+	ID      string   `json:"id,omitempty"`      // refresh
+}
+
+// Parse decodes and validates a message. An empty body is a full update, as sent before messages had a schema.
+func Parse(data []byte) (Message, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return Message{Version: Version, Type: TypeUpdate}, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m Message
+	if err := dec.Decode(&m); err != nil {
+		return Message{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if m.Version != Version {
+		return Message{}, fmt.Errorf("%w: unsupported version %d", ErrInvalid, m.Version)
+	}
+	if err := m.Validate(); err != nil {
+		return Message{}, err
+	}
+	return m, nil
+}
+
+// Validate checks that the message has the fields its type needs.
+func (m Message) Validate() error {
+	switch m.Type {
+	case TypeUpdate:
+	case TypeBackfill:
+		from, err := parseDate(m.From)
+		if err != nil {
+			return fmt.Errorf("%w: from: %v", ErrInvalid, err)
+		}
+		to, err := parseDate(m.To)
+		if err != nil {
+			return fmt.Errorf("%w: to: %v", ErrInvalid, err)
+		}
+		if to.Before(from) {
+			return fmt.Errorf("%w: from %d is after to %d", ErrInvalid, m.From, m.To)
+		}
+	case TypeReannotate:
+		if len(m.IDs) == 0 {
+			return fmt.Errorf("%w: no ids to re-annotate", ErrInvalid)
+		}
+	case TypeRefresh:
+		if m.ID == "" {
+			return fmt.Errorf("%w: no id to refresh", ErrInvalid)
+		}
+	case "":
+		return fmt.Errorf("%w: missing type", ErrInvalid)
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalid, m.Type)
+	}
+	return nil
+}
+
+// parseDate parses a date in the form 20250101.
+func parseDate(date int) (time.Time, error) {
+	return time.Parse("20060102", strconv.Itoa(date))
+}
+
+// Handler performs the operation of a message.
+type Handler func(ctx context.Context, m Message) error
+
+// Registry dispatches messages to the handler of their type.
+type Registry struct {
+	handlers map[string]Handler
+}
+
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]Handler)}
+}
+
+// Handle registers the handler of a message type, replacing any previous one.
+func (r *Registry) Handle(typ string, h Handler) {
+	r.handlers[typ] = h
+}
+
+// Dispatch parses a message and calls the handler of its type.
+func (r *Registry) Dispatch(ctx context.Context, data []byte) error {
+	m, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	h, ok := r.handlers[m.Type]
+	if !ok {
+		return fmt.Errorf("%w: no handler for type %q", ErrInvalid, m.Type)
+	}
+	return h(ctx, m)
+}
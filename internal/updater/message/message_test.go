@@ -0,0 +1,61 @@
+package message_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"api/internal/updater/message"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	m, err := message.Parse(nil)
+	require.NoError(t, err)
+	assert.Equal(t, message.Message{Version: 1, Type: message.TypeUpdate}, m)
+
+	m, err = message.Parse([]byte(`{"version":1,"type":"backfill","from":20250101,"to":20250107,"markets":["en-US"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, message.Message{Version: 1, Type: message.TypeBackfill, From: 20250101, To: 20250107, Markets: []string{"en-US"}}, m)
+
+	for _, body := range []string{
+		`{"type":"update"}`,
+		`{"version":2,"type":"update"}`,
+		`{"version":1}`,
+		`{"version":1,"type":"delete"}`,
+		`{"version":1,"type":"update","market":"en-US"}`,
+		`{"version":1,"type":"backfill","from":20250107,"to":20250101}`,
+		`{"version":1,"type":"backfill","from":20250132,"to":20250201}`,
+		`{"version":1,"type":"reannotate","ids":[]}`,
+		`{"version":1,"type":"refresh"}`,
+		`update`,
+	} {
+		_, err := message.Parse([]byte(body))
+		assert.ErrorIs(t, err, message.ErrInvalid, body)
+	}
+}
+
+func TestRegistry_Dispatch(t *testing.T) {
+	r := message.NewRegistry()
+
+	var refreshed string
+	r.Handle(message.TypeRefresh, func(_ context.Context, m message.Message) error {
+		refreshed = m.ID
+		return nil
+	})
+	r.Handle(message.TypeUpdate, func(context.Context, message.Message) error {
+		return errors.New("bing is down")
+	})
+
+	require.NoError(t, r.Dispatch(context.Background(), []byte(`{"version":1,"type":"refresh","id":"PresDayDC"}`)))
+	assert.Equal(t, "PresDayDC", refreshed)
+
+	err := r.Dispatch(context.Background(), nil)
+	assert.EqualError(t, err, "bing is down")
+	assert.NotErrorIs(t, err, message.ErrInvalid)
+
+	err = r.Dispatch(context.Background(), []byte(`{"version":1,"type":"reannotate","ids":["PresDayDC"]}`))
+	assert.ErrorIs(t, err, message.ErrInvalid)
+}
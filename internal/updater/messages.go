@@ -0,0 +1,47 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"api/internal/updater/message"
+)
+
+// operations maps each message type to the operation performing it.
+func (u *Updater) operations() map[string]operation {
+	return map[string]operation{
+		message.TypeUpdate: func(ctx context.Context, m message.Message, res *Result) error {
+			if err := validateMarkets(m.Markets); err != nil {
+				return err
+			}
+			return u.UpdateMarkets(ctx, m.Markets, res)
+		},
+		message.TypeBackfill: func(ctx context.Context, m message.Message, res *Result) error {
+			if err := validateMarkets(m.Markets); err != nil {
+				return err
+			}
+			return u.Backfill(ctx, m.From, m.To, m.Markets, res)
+		},
+		message.TypeReannotate: func(ctx context.Context, m message.Message, res *Result) error {
+			r, err := u.ReannotateIDs(ctx, m.IDs)
+			if r != nil {
+				res.Updated = r.Updated
+			}
+			return err
+		},
+		message.TypeRefresh: func(ctx context.Context, m message.Message, res *Result) error {
+			return u.Refresh(ctx, m.ID, res)
+		},
+	}
+}
+
+// validateMarkets checks that the markets requested by a message are ones the updater fetches.
+func validateMarkets(markets []string) error {
+	for _, market := range markets {
+		if !slices.Contains(ENMarkets, market) && !slices.Contains(nonENMarkets, market) {
+			return fmt.Errorf("%w: unknown market %q", message.ErrInvalid, market)
+		}
+	}
+	return nil
+}
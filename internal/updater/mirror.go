@@ -0,0 +1,139 @@
+package updater
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+
+	"api/internal/updater/blob"
+	"api/internal/updater/firestore"
+	imgpkg "api/internal/updater/image"
+
+	"cloud.google.com/go/storage"
+)
+
+// newBlobStore configures where images are mirrored from MIRROR, which is off by default.
+func newBlobStore(ctx context.Context) (blob.Store, error) {
+	switch m := os.Getenv("MIRROR"); m {
+	case "", "off":
+		return nil, nil
+	case "fs":
+		dir := os.Getenv("MIRROR_DIR")
+		if dir == "" {
+			return nil, fmt.Errorf("MIRROR_DIR is required for the fs mirror")
+		}
+		// copies are only reachable through the URL they are served from
+		baseURL := os.Getenv("MIRROR_URL")
+		if baseURL == "" {
+			return nil, fmt.Errorf("MIRROR_URL is required for the fs mirror")
+		}
+		return &blob.FS{Dir: dir, BaseURL: baseURL}, nil
+	case "gcs":
+		bucket := os.Getenv("MIRROR_BUCKET")
+		if bucket == "" {
+			return nil, fmt.Errorf("MIRROR_BUCKET is required for the gcs mirror")
+		}
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return blob.NewGCS(client, bucket, os.Getenv("MIRROR_URL")), nil
+	default:
+		return nil, fmt.Errorf("invalid mirror %q", m)
+	}
+}
+
+// mirrorKey is where a resolution variant of an image is stored, e.g. "PresDayDC/UHD.jpg".
+func mirrorKey(id, resolution string) string {
+	return id + "/" + resolution + ".jpg"
+}
+
+// mirror copies the resolution variants of an image that aren't mirrored yet into the blob store,
+// reporting whether any were. Failed variants are logged and left to be served from Bing.
+func (u *Updater) mirror(ctx context.Context, image *imgpkg.Image) (bool, error) {
+	if u.blobs == nil {
+		return false, nil
+	}
+
+	changed := false
+	for _, res := range image.Resolutions {
+		if _, ok := image.Mirrors[res]; ok {
+			continue
+		}
+
+		data, err := u.fetcher.Get(ctx, image.URLFor(res))
+		if err == nil {
+			err = u.blobs.Put(ctx, mirrorKey(image.ID, res), data, "image/jpeg")
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return changed, ctx.Err()
+			}
+			log.Printf("warning: failed to mirror %s %s: %v", image.ID, res, err)
+			continue
+		}
+
+		sum := sha256.Sum256(data)
+		if image.Mirrors == nil {
+			image.Mirrors = make(map[string]imgpkg.Mirror)
+		}
+		image.Mirrors[res] = imgpkg.Mirror{
+			URL:    u.blobs.URL(mirrorKey(image.ID, res)),
+			SHA256: hex.EncodeToString(sum[:]),
+			Size:   len(data),
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// MirrorAll mirrors the variants of every stored image that aren't mirrored yet,
+// probing those of images stored before variants were recorded, and returns the IDs of the images that were mirrored.
+func (u *Updater) MirrorAll(ctx context.Context) ([]string, error) {
+	if u.blobs == nil {
+		return nil, fmt.Errorf("mirroring is off, set MIRROR")
+	}
+
+	updated := []string{}
+	q := firestore.ListQuery{Limit: reannotatePageSize}
+	for {
+		images, err := u.firestoreClient.List(ctx, q)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, image := range images {
+			if err := u.probeStored(ctx, &image); err != nil {
+				if ctx.Err() != nil {
+					return updated, ctx.Err()
+				}
+				log.Printf("warning: %v", err)
+				continue
+			}
+
+			changed, err := u.mirror(ctx, &image)
+			if err != nil {
+				return updated, err
+			}
+			if !changed {
+				continue
+			}
+			if err := u.firestoreClient.UpdateMirrors(ctx, image); err != nil {
+				return updated, err
+			}
+			updated = append(updated, image.ID)
+		}
+
+		if len(images) < q.Limit {
+			break
+		}
+		last := images[len(images)-1]
+		q.StartAfterDate, q.StartAfterID = last.Date, last.ID
+	}
+
+	fmt.Printf("%d images mirrored\n", len(updated))
+	return updated, nil
+}
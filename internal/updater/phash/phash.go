@@ -0,0 +1,83 @@
+package phash
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register decoder for Bing images
+	_ "image/png"  // register decoder for tests and mirrors
+	"math/bits"
+	"strconv"
+)
+
+const (
+	width  = 9
+	height = 8
+)
+
+// DHash computes a 64-bit difference hash of img.
+// The image is reduced to 9x8 grayscale and each bit records whether a pixel is brighter than its right neighbour.
+func DHash(img image.Image) uint64 {
+	gray := shrink(img)
+
+	var hash uint64
+	for y := range height {
+		for x := range width - 1 {
+			hash <<= 1
+			if gray[y][x] > gray[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// FromBytes decodes an encoded image and returns its DHash.
+func FromBytes(b []byte) (uint64, error) {
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return DHash(img), nil
+}
+
+// Distance returns the number of differing bits between two hashes.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Format returns the hash as a 16 character hex string.
+func Format(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// Parse parses a hash produced by Format.
+func Parse(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
+
+// shrink averages img down to a width x height grid of luma values.
+func shrink(img image.Image) [height][width]float64 {
+	var sums [height][width]float64
+	var counts [height][width]int
+
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		gy := (y - b.Min.Y) * height / b.Dy()
+		for x := b.Min.X; x < b.Max.X; x++ {
+			gx := (x - b.Min.X) * width / b.Dx()
+			r, g, bl, _ := img.At(x, y).RGBA()
+			sums[gy][gx] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+			counts[gy][gx]++
+		}
+	}
+
+	for y := range height {
+		for x := range width {
+			if counts[y][x] > 0 {
+				sums[y][x] /= float64(counts[y][x])
+			}
+		}
+	}
+	return sums
+}
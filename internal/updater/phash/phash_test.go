@@ -0,0 +1,47 @@
+package phash_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"api/internal/updater/phash"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gradient draws a diagonal gradient, optionally mirrored horizontally.
+func gradient(w, h int, mirrored bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			fx := x
+			if mirrored {
+				fx = w - 1 - x
+			}
+			v := uint8((fx*255/w + y*128/h) / 2)
+			img.Set(x, y, color.RGBA{R: v, G: v / 2, B: 255 - v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestDHash(t *testing.T) {
+	original := phash.DHash(gradient(1920, 1200, false))
+	resized := phash.DHash(gradient(960, 540, false))
+	different := phash.DHash(gradient(1920, 1200, true))
+
+	assert.LessOrEqual(t, phash.Distance(original, resized), 4)
+	assert.Greater(t, phash.Distance(original, different), 32)
+}
+
+func TestFormatParse(t *testing.T) {
+	hash := uint64(0x00f0_1234_abcd_ef00)
+	s := phash.Format(hash)
+	assert.Equal(t, "00f01234abcdef00", s)
+
+	got, err := phash.Parse(s)
+	require.NoError(t, err)
+	assert.Equal(t, hash, got)
+}
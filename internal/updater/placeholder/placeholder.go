@@ -0,0 +1,166 @@
+package placeholder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png" // register decoder for tests and mirrors
+	"math"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// xComponents and yComponents are the BlurHash detail, suiting landscape wallpapers.
+	xComponents = 4
+	yComponents = 3
+
+	// hashWidth is the width images are shrunk to before hashing, which hardly changes the result.
+	hashWidth = 64
+
+	// lqipWidth and lqipQuality keep previews to around a kilobyte.
+	lqipWidth   = 32
+	lqipQuality = 60
+)
+
+// Placeholders are shown while a wallpaper loads.
+type Placeholders struct {
+	BlurHash string
+	LQIP     string // Data URI of a tiny JPEG
+}
+
+// FromBytes decodes an encoded image and returns its placeholders.
+func FromBytes(b []byte) (Placeholders, error) {
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return Placeholders{}, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	lqip, err := LQIP(img)
+	if err != nil {
+		return Placeholders{}, err
+	}
+	return Placeholders{BlurHash: BlurHash(shrink(img, hashWidth), xComponents, yComponents), LQIP: lqip}, nil
+}
+
+// LQIP returns a base64 data URI of img shrunk to a tiny JPEG.
+func LQIP(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, shrink(img, lqipWidth), &jpeg.Options{Quality: lqipQuality}); err != nil {
+		return "", fmt.Errorf("failed to encode preview: %w", err)
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// shrink scales img to the given width, keeping its aspect ratio.
+func shrink(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= width {
+		return img
+	}
+	height := max(1, b.Dy()*width/b.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// BlurHash encodes img with the given number of components (1 to 9) on each axis,
+// following https://github.com/woltapp/blurhash.
+func BlurHash(img image.Image, xComponents, yComponents int) string {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// linear RGB of each pixel, so the cosine transform doesn't redo the conversion per component
+	linear := make([][3]float64, w*h)
+	for y := range h {
+		for x := range w {
+			r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			linear[y*w+x] = [3]float64{toLinear(r >> 8), toLinear(g >> 8), toLinear(bl >> 8)}
+		}
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := range yComponents {
+		for i := range xComponents {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+
+			var f [3]float64
+			for y := range h {
+				cy := math.Cos(math.Pi * float64(j) * float64(y) / float64(h))
+				for x := range w {
+					basis := math.Cos(math.Pi*float64(i)*float64(x)/float64(w)) * cy
+					for c := range 3 {
+						f[c] += basis * linear[y*w+x][c]
+					}
+				}
+			}
+
+			scale := normalisation / float64(w*h)
+			for c := range 3 {
+				f[c] *= scale
+			}
+			factors = append(factors, f)
+		}
+	}
+
+	hash := encode83((xComponents-1)+(yComponents-1)*9, 1)
+
+	dc, ac := factors[0], factors[1:]
+	maximum := 1.0
+	if len(ac) > 0 {
+		var actual float64
+		for _, f := range ac {
+			actual = max(actual, math.Abs(f[0]), math.Abs(f[1]), math.Abs(f[2]))
+		}
+		quantised := int(max(0, min(82, math.Floor(actual*166-0.5))))
+		maximum = float64(quantised+1) / 166
+		hash += encode83(quantised, 1)
+	} else {
+		hash += encode83(0, 1)
+	}
+
+	hash += encode83(toSRGB(dc[0])<<16|toSRGB(dc[1])<<8|toSRGB(dc[2]), 4)
+	for _, f := range ac {
+		q := func(v float64) int {
+			return int(max(0, min(18, math.Floor(signPow(v/maximum, 0.5)*9+9.5))))
+		}
+		hash += encode83(q(f[0])*19*19+q(f[1])*19+q(f[2]), 2)
+	}
+	return hash
+}
+
+const base83 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+func encode83(v, length int) string {
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		out[i] = base83[v%83]
+		v /= 83
+	}
+	return string(out)
+}
+
+func toLinear(v uint32) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+func toSRGB(v float64) int {
+	v = max(0, min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
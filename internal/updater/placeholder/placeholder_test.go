@@ -0,0 +1,60 @@
+package placeholder_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"api/internal/updater/placeholder"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gradient(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, color.RGBA{uint8(x * 4), uint8(y * 6), uint8(255 - x*2), 255})
+		}
+	}
+	return img
+}
+
+func TestBlurHash(t *testing.T) {
+	assert.Equal(t, "L#HLGz2f$8SimHa#jtf8gJfjfQfj", placeholder.BlurHash(gradient(64, 40), 4, 3))
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := range 8 {
+		for y := range 8 {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	// the average color is exact, even though sampling from the pixel edges leaks into the AC components
+	assert.Equal(t, "LfTI:j|cfQ|c|csUfQsUfQfQfQfQ", placeholder.BlurHash(img, 4, 3))
+}
+
+func TestFromBytes(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, gradient(64, 40)))
+
+	p, err := placeholder.FromBytes(buf.Bytes())
+	require.NoError(t, err)
+	assert.Len(t, p.BlurHash, 28)
+
+	data, ok := strings.CutPrefix(p.LQIP, "data:image/jpeg;base64,")
+	require.True(t, ok)
+	b, err := base64.StdEncoding.DecodeString(data)
+	require.NoError(t, err)
+	preview, err := jpeg.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(32, 20), preview.Bounds().Size())
+
+	_, err = placeholder.FromBytes([]byte("not an image"))
+	assert.Error(t, err)
+}
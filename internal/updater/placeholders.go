@@ -0,0 +1,69 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"api/internal/updater/firestore"
+	imgpkg "api/internal/updater/image"
+	"api/internal/updater/placeholder"
+)
+
+// setPlaceholders renders the BlurHash and preview shown while an image loads.
+func setPlaceholders(image *imgpkg.Image, imgBytes []byte) error {
+	p, err := placeholder.FromBytes(imgBytes)
+	if err != nil {
+		return fmt.Errorf("failed to render placeholders of %s: %w", image.ID, err)
+	}
+	image.BlurHash, image.LQIP = p.BlurHash, p.LQIP
+	return nil
+}
+
+// BackfillPlaceholders downloads stored images without placeholders, or all of them if force is set,
+// and saves their placeholders. It returns the IDs of the images that were updated.
+// Images that fail to download are logged and skipped.
+func (u *Updater) BackfillPlaceholders(ctx context.Context, force bool) ([]string, error) {
+	updated := []string{}
+
+	q := firestore.ListQuery{Limit: reannotatePageSize}
+	for {
+		images, err := u.firestoreClient.List(ctx, q)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, image := range images {
+			if image.BlurHash != "" && !force {
+				continue
+			}
+
+			imgBytes, err := u.download(ctx, image.URL())
+			if err != nil {
+				if ctx.Err() != nil {
+					return updated, ctx.Err()
+				}
+				log.Printf("warning: %s: %v", image.ID, err)
+				continue
+			}
+			if err := setPlaceholders(&image, imgBytes); err != nil {
+				log.Printf("warning: %v", err)
+				continue
+			}
+
+			if err := u.firestoreClient.UpdatePlaceholders(ctx, image); err != nil {
+				return updated, err
+			}
+			updated = append(updated, image.ID)
+		}
+
+		if len(images) < q.Limit {
+			break
+		}
+		last := images[len(images)-1]
+		q.StartAfterDate, q.StartAfterID = last.Date, last.ID
+	}
+
+	fmt.Printf("%d images updated\n", len(updated))
+	return updated, nil
+}
@@ -0,0 +1,123 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/internal/fetch"
+	"api/internal/updater/jpeginfo"
+)
+
+// Prober checks which resolution variants of a Bing image exist.
+type Prober struct {
+	Fetcher *fetch.Fetcher
+}
+
+// Probe returns the resolutions, in the given order, for which urlBase + "_" + resolution + ".jpg" exists.
+func (p *Prober) Probe(ctx context.Context, urlBase string, resolutions []string) ([]string, error) {
+	var found []string
+	for _, res := range resolutions {
+		ok, err := p.exists(ctx, urlBase+"_"+res+".jpg")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, res)
+		}
+	}
+	return found, nil
+}
+
+// exists sends a HEAD request, falling back to a single byte range request if HEAD isn't allowed.
+func (p *Prober) exists(ctx context.Context, url string) (bool, error) {
+	resp, err := p.do(ctx, http.MethodHead, url)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		resp, err = p.do(ctx, http.MethodGet, url)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		// Bing may serve a placeholder page instead of a 404
+		return strings.HasPrefix(resp.Header.Get("Content-Type"), "image/"), nil
+	case http.StatusNotFound, http.StatusGone:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to probe %s: status %d", url, resp.StatusCode)
+	}
+}
+
+func (p *Prober) do(ctx context.Context, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create probe request: %w", err)
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+
+	resp, err := p.Fetcher.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe %s: %w", url, err)
+	}
+	_ = resp.Body.Close()
+
+	return resp, nil
+}
+
+// headerSize is how much of an image Measure fetches, enough for the frame header of Bing's JPEGs.
+const headerSize = 64 << 10
+
+// Measure reads the size and quality of a JPEG from its header, and its length in bytes.
+func (p *Prober) Measure(ctx context.Context, url string) (jpeginfo.Info, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return jpeginfo.Info{}, 0, fmt.Errorf("failed to create measure request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", headerSize-1))
+
+	resp, err := p.Fetcher.Do(req)
+	if err != nil {
+		return jpeginfo.Info{}, 0, err
+	}
+	defer resp.Body.Close()
+
+	var size int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// e.g. "bytes 0-65535/1234567"
+		_, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/")
+		if ok {
+			size, _ = strconv.ParseInt(total, 10, 64)
+		}
+	case http.StatusOK:
+		// ranges aren't supported, so the whole image is being sent
+		size = resp.ContentLength
+	default:
+		return jpeginfo.Info{}, 0, fmt.Errorf("failed to measure %s: status %d", url, resp.StatusCode)
+	}
+
+	header, err := io.ReadAll(io.LimitReader(resp.Body, headerSize))
+	if err != nil {
+		return jpeginfo.Info{}, 0, fmt.Errorf("failed to measure %s: %w", url, err)
+	}
+	if size <= 0 && len(header) < headerSize {
+		size = int64(len(header))
+	}
+
+	info, err := jpeginfo.Parse(header)
+	if err != nil {
+		return jpeginfo.Info{}, 0, fmt.Errorf("failed to measure %s: %w", url, err)
+	}
+	return info, int(size), nil
+}
@@ -0,0 +1,71 @@
+package probe_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/fetch"
+	"api/internal/updater/jpeginfo"
+	"api/internal/updater/probe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProber_Probe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "_UHD.jpg"):
+			// HEAD not allowed, only ranged GET
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			assert.Equal(t, "bytes=0-0", r.Header.Get("Range"))
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.WriteHeader(http.StatusPartialContent)
+		case strings.HasSuffix(r.URL.Path, "_1920x1080.jpg"):
+			w.Header().Set("Content-Type", "image/jpeg")
+		case strings.HasSuffix(r.URL.Path, "_1366x768.jpg"):
+			w.Header().Set("Content-Type", "text/html")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := probe.Prober{Fetcher: &fetch.Fetcher{HC: &http.Client{Timeout: time.Second}}}
+	got, err := p.Probe(context.Background(), server.URL+"/img", []string{"UHD", "1920x1200", "1920x1080", "1366x768"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"UHD", "1920x1080"}, got)
+}
+
+func TestProber_Measure(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 192, 120)), &jpeg.Options{Quality: 85}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/norange") {
+			_, _ = w.Write(buf.Bytes())
+			return
+		}
+		http.ServeContent(w, r, "img.jpg", time.Time{}, bytes.NewReader(buf.Bytes()))
+	}))
+	defer server.Close()
+
+	p := probe.Prober{Fetcher: &fetch.Fetcher{HC: &http.Client{Timeout: time.Second}}}
+	for _, path := range []string{"/img_1920x1200.jpg", "/norange_1920x1200.jpg"} {
+		info, size, err := p.Measure(context.Background(), server.URL+path)
+		require.NoError(t, err, path)
+		assert.Equal(t, jpeginfo.Info{Width: 192, Height: 120, Quality: 85}, info, path)
+		assert.Equal(t, buf.Len(), size, path)
+	}
+}
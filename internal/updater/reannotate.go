@@ -0,0 +1,206 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"api/internal/updater/firestore"
+	imgpkg "api/internal/updater/image"
+)
+
+const (
+	checkpointsCollection = "UpdaterCheckpoints"
+	auditCollection       = "AnnotationAudit"
+
+	reannotatePageSize        = 100
+	defaultReannotateInterval = time.Second
+)
+
+// ReannotateOptions configures Reannotate.
+type ReannotateOptions struct {
+	FromDate int // Inclusive, ignored if zero
+	ToDate   int // Inclusive, ignored if zero
+
+	// MissingOnly only re-annotates images without tags or colors.
+	MissingOnly bool
+	// Limit stops after this many images have been re-annotated, ignored if zero.
+	Limit int
+	// Interval is the minimum time between annotations, defaults to one second.
+	Interval time.Duration
+	// Checkpoint names the saved position, so an interrupted run resumes where it stopped.
+	// Runs without a name always start from the beginning.
+	Checkpoint string
+}
+
+// ReannotateResult summarises a Reannotate run.
+type ReannotateResult struct {
+	Processed int      `json:"processed"`
+	Updated   []string `json:"updated"`
+}
+
+// Reannotate re-runs annotation for stored images in date order, updating their tags, colors,
+// landmarks, web entities, safe-search and location, and recording an audit of tag and color changes.
+func (u *Updater) Reannotate(ctx context.Context, opts ReannotateOptions) (*ReannotateResult, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultReannotateInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	q := firestore.ListQuery{FromDate: opts.FromDate, ToDate: opts.ToDate, Limit: reannotatePageSize}
+	var cp firestore.Checkpoint
+	if opts.Checkpoint != "" {
+		saved, err := u.firestoreClient.GetCheckpoint(ctx, checkpointsCollection, opts.Checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		if saved != nil {
+			fmt.Printf("resuming after %s (%d)\n", saved.ID, saved.Date)
+			cp = *saved
+			q.StartAfterDate, q.StartAfterID = saved.Date, saved.ID
+		}
+	}
+
+	result := &ReannotateResult{Updated: []string{}}
+	for {
+		images, err := u.firestoreClient.List(ctx, q)
+		if err != nil {
+			return result, err
+		}
+
+		for _, image := range images {
+			if opts.Limit > 0 && result.Processed >= opts.Limit {
+				return result, nil
+			}
+
+			// skipped images are checkpointed with the page, rather than one write each
+			if opts.MissingOnly && len(image.Tags) > 0 && len(image.Colors) > 0 {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-ticker.C:
+			}
+
+			changed, err := u.reannotate(ctx, image)
+			if err != nil {
+				return result, err
+			}
+			result.Processed++
+			if changed {
+				result.Updated = append(result.Updated, image.ID)
+			}
+
+			cp.Processed++
+			if err := u.checkpoint(ctx, opts.Checkpoint, &cp, image); err != nil {
+				return result, err
+			}
+		}
+
+		if len(images) > 0 {
+			if err := u.checkpoint(ctx, opts.Checkpoint, &cp, images[len(images)-1]); err != nil {
+				return result, err
+			}
+		}
+
+		if len(images) < q.Limit {
+			break
+		}
+		last := images[len(images)-1]
+		q.StartAfterDate, q.StartAfterID = last.Date, last.ID
+	}
+
+	if opts.Checkpoint != "" {
+		if err := u.firestoreClient.DeleteCheckpoint(ctx, checkpointsCollection, opts.Checkpoint); err != nil {
+			return result, err
+		}
+	}
+
+	fmt.Printf("%d images re-annotated, %d updated\n", result.Processed, len(result.Updated))
+	return result, nil
+}
+
+// checkpoint saves the position after image under name, unless it is already saved or name is empty.
+func (u *Updater) checkpoint(ctx context.Context, name string, cp *firestore.Checkpoint, image imgpkg.Image) error {
+	if name == "" || (cp.Date == image.Date && cp.ID == image.ID) {
+		return nil
+	}
+
+	cp.Date, cp.ID, cp.UpdatedAt = image.Date, image.ID, time.Now()
+	return u.firestoreClient.SetCheckpoint(ctx, checkpointsCollection, name, *cp)
+}
+
+// ReannotateIDs re-annotates the given stored images, like Reannotate. Images that don't exist are logged and skipped.
+func (u *Updater) ReannotateIDs(ctx context.Context, ids []string) (*ReannotateResult, error) {
+	result := &ReannotateResult{Updated: []string{}}
+	for _, id := range ids {
+		image, err := u.firestoreClient.Get(ctx, id)
+		if err != nil {
+			return result, err
+		}
+		if image == nil {
+			log.Printf("warning: wallpaper %s not found", id)
+			continue
+		}
+
+		changed, err := u.reannotate(ctx, *image)
+		if err != nil {
+			return result, err
+		}
+		result.Processed++
+		if changed {
+			result.Updated = append(result.Updated, id)
+		}
+	}
+
+	fmt.Printf("%d images re-annotated, %d updated\n", result.Processed, len(result.Updated))
+	return result, nil
+}
+
+// reannotate annotates a stored image again and saves its tags and colors if they changed.
+func (u *Updater) reannotate(ctx context.Context, image imgpkg.Image) (bool, error) {
+	imgBytes, err := u.download(ctx, image.URL())
+	if err != nil {
+		return false, err
+	}
+
+	updated := image
+	updated.Tags = make(map[string]float32)
+	updated.TagsOrdered = nil
+	updated.Colors = nil
+	if err := u.enrich(ctx, &updated, imgBytes); err != nil {
+		return false, err
+	}
+	if err := u.locate(ctx, &updated); err != nil {
+		return false, err
+	}
+
+	if maps.Equal(image.Tags, updated.Tags) && slices.Equal(image.Colors, updated.Colors) &&
+		reflect.DeepEqual(image.Landmarks, updated.Landmarks) &&
+		reflect.DeepEqual(image.WebEntities, updated.WebEntities) &&
+		reflect.DeepEqual(image.SafeSearch, updated.SafeSearch) &&
+		image.Geohash == updated.Geohash && image.Country == updated.Country {
+		return false, nil
+	}
+
+	if err := u.firestoreClient.UpdateAnnotations(ctx, updated); err != nil {
+		return false, err
+	}
+
+	return true, u.firestoreClient.AddAudit(ctx, auditCollection, firestore.AnnotationAudit{
+		ID:        image.ID,
+		At:        time.Now(),
+		OldTags:   image.Tags,
+		NewTags:   updated.Tags,
+		OldColors: image.Colors,
+		NewColors: updated.Colors,
+	})
+}
@@ -0,0 +1,102 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"api/internal/updater/lock"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule is a cron expression evaluated in a time zone.
+type Schedule struct {
+	spec cron.Schedule
+	loc  *time.Location
+}
+
+// Parse parses a standard five-field cron expression, or a descriptor such as "@daily",
+// in the named IANA time zone (UTC if empty).
+func Parse(expr, timezone string) (*Schedule, error) {
+	spec, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule time zone %q: %w", timezone, err)
+		}
+	}
+	return &Schedule{spec: spec, loc: loc}, nil
+}
+
+// Next returns the first scheduled time after t.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.spec.Next(t.In(s.loc))
+}
+
+// Scheduler calls Run at every scheduled time, delayed by up to Jitter.
+// If Lock is set, only the instance that acquires the lease for a scheduled time runs it,
+// and no run starts while another one holds the lease.
+type Scheduler struct {
+	Schedule *Schedule
+	Run      func(ctx context.Context) error
+	Jitter   time.Duration
+	Lock     lock.Locker
+	LockName string
+	LockTTL  time.Duration // How long the lease is held without being renewed
+
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+// Start runs the schedule until ctx is done. Failed runs are logged and wait for the next scheduled time.
+func (s *Scheduler) Start(ctx context.Context) error {
+	now, after := s.now, s.after
+	if now == nil {
+		now = time.Now
+	}
+	if after == nil {
+		after = time.After
+	}
+
+	log.Printf("image updater scheduled, next run at %s", s.Schedule.Next(now()))
+	for {
+		tick := s.Schedule.Next(now())
+		wait := tick.Sub(now())
+		if s.Jitter > 0 {
+			wait += rand.N(s.Jitter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-after(wait):
+		}
+
+		if err := s.runOnce(ctx, tick); err != nil {
+			log.Printf("scheduled update at %s failed: %v", tick, err)
+		}
+	}
+}
+
+// runOnce runs the scheduled time tick, unless another instance holds the lease or already ran it.
+func (s *Scheduler) runOnce(ctx context.Context, tick time.Time) error {
+	if s.Lock == nil {
+		return s.Run(ctx)
+	}
+
+	ran, err := lock.Hold(ctx, s.Lock, s.LockName, tick.UTC().Format(time.RFC3339), s.LockTTL, func(ctx context.Context, _ lock.Handle) error {
+		return s.Run(ctx)
+	})
+	if !ran && err == nil {
+		log.Printf("skipping scheduled update at %s, another instance has it", tick)
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"api/internal/updater/lock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("30 0 * * *", "America/New_York")
+	require.NoError(t, err)
+
+	from := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, time.Date(2025, 3, 2, 5, 30, 0, 0, time.UTC).Equal(s.Next(from)))
+
+	s, err = Parse("@hourly", "")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2025, 3, 1, 13, 0, 0, 0, time.UTC).Equal(s.Next(from)))
+
+	_, err = Parse("every day", "")
+	assert.Error(t, err)
+	_, err = Parse("@daily", "Mars/Olympus_Mons")
+	assert.Error(t, err)
+}
+
+// claimed is a lease another instance already acquired for key.
+type claimed struct {
+	lock.Locker
+	key string
+}
+
+func (c claimed) Acquire(ctx context.Context, name, key string, ttl time.Duration) (lock.Handle, bool, error) {
+	if key == c.key {
+		return lock.Handle{}, false, nil
+	}
+	return c.Locker.Acquire(ctx, name, key, ttl)
+}
+
+func TestScheduler_Start(t *testing.T) {
+	sched, err := Parse("@hourly", "")
+	require.NoError(t, err)
+
+	clock := time.Date(2025, 3, 1, 12, 10, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var waits []time.Duration
+	runs := 0
+	locker := claimed{Locker: lock.NewMemory(), key: "2025-03-01T14:00:00Z"}
+	s := &Scheduler{
+		Schedule: sched,
+		Lock:     locker,
+		LockName: "update",
+		LockTTL:  time.Minute,
+		Jitter:   time.Minute,
+		Run: func(context.Context) error {
+			runs++
+			return errors.New("failed runs wait for the next one")
+		},
+		now: func() time.Time { return clock },
+		after: func(d time.Duration) <-chan time.Time {
+			waits = append(waits, d)
+			if len(waits) == 3 {
+				cancel()
+				return nil
+			}
+			clock = clock.Add(d)
+			ch := make(chan time.Time, 1)
+			ch <- clock
+			return ch
+		},
+	}
+	require.NoError(t, s.Start(ctx))
+
+	// 13:00 runs, 14:00 was already run elsewhere, then the context is done
+	assert.Equal(t, 1, runs)
+	require.Len(t, waits, 3)
+	assert.GreaterOrEqual(t, waits[0], 50*time.Minute)
+	assert.Less(t, waits[0], 51*time.Minute)
+}
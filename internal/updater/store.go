@@ -0,0 +1,37 @@
+package updater
+
+import (
+	"context"
+	"time"
+
+	"api/internal/updater/firestore"
+	imgpkg "api/internal/updater/image"
+)
+
+// store is where the updater reads and writes wallpapers and its records, implemented by *firestore.Client.
+type store interface {
+	Get(ctx context.Context, id string) (*imgpkg.Image, error)
+	GetByAlias(ctx context.Context, id string) (*imgpkg.Image, error)
+	ListHashes(ctx context.Context) (map[string]string, error)
+	List(ctx context.Context, q firestore.ListQuery) ([]imgpkg.Image, error)
+	Upsert(ctx context.Context, img imgpkg.Image) error
+
+	UpdateAnnotations(ctx context.Context, img imgpkg.Image) error
+	UpdatePlaceholders(ctx context.Context, img imgpkg.Image) error
+	UpdateResolutions(ctx context.Context, img imgpkg.Image) error
+	UpdateHash(ctx context.Context, img imgpkg.Image) error
+	UpdateVariants(ctx context.Context, img imgpkg.Image) error
+	UpdateMirrors(ctx context.Context, img imgpkg.Image) error
+	UpdateAttribution(ctx context.Context, img imgpkg.Image) error
+
+	AddPhotographer(ctx context.Context, collection string, img imgpkg.Image) error
+	SetPhotographers(ctx context.Context, collection string, photographers []firestore.Photographer) error
+
+	GetCheckpoint(ctx context.Context, collection, name string) (*firestore.Checkpoint, error)
+	SetCheckpoint(ctx context.Context, collection, name string, cp firestore.Checkpoint) error
+	DeleteCheckpoint(ctx context.Context, collection, name string) error
+	AddAudit(ctx context.Context, collection string, a firestore.AnnotationAudit) error
+
+	SetJob(ctx context.Context, collection string, job firestore.Job) error
+	DeleteJobsBefore(ctx context.Context, collection string, t time.Time) (int, error)
+}
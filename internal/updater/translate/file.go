@@ -0,0 +1,72 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// FileCache is a Cache stored as a JSON object in a local file.
+type FileCache struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (c *FileCache) Get(_ context.Context, keys []string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	all, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if v, ok := all[k]; ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+func (c *FileCache) Set(_ context.Context, translations map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	all, err := c.read()
+	if err != nil {
+		return err
+	}
+	for k, v := range translations {
+		all[k] = v
+	}
+
+	b, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, b, 0o600)
+}
+
+func (c *FileCache) read() (map[string]string, error) {
+	all := make(map[string]string)
+
+	b, err := os.ReadFile(c.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse translation cache %s: %w", c.Path, err)
+	}
+	return all, nil
+}
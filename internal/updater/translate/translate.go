@@ -0,0 +1,178 @@
+package translate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	gtranslate "cloud.google.com/go/translate"
+	"golang.org/x/text/language"
+)
+
+// maxBatch is the most texts the Google Translate API accepts in one request.
+const maxBatch = 128
+
+// Translator translates texts into the target language, returning them in the same order.
+type Translator interface {
+	Translate(ctx context.Context, texts []string, target language.Tag) ([]string, error)
+}
+
+// Google translates using the Google Cloud Translation API, detecting the source language of each text.
+type Google struct {
+	Client *gtranslate.Client
+}
+
+func NewGoogle(ctx context.Context) (*Google, error) {
+	client, err := gtranslate.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Google{Client: client}, nil
+}
+
+func (g *Google) Translate(ctx context.Context, texts []string, target language.Tag) ([]string, error) {
+	out := make([]string, 0, len(texts))
+	opts := &gtranslate.Options{Format: "text"}
+
+	for start := 0; start < len(texts); start += maxBatch {
+		batch := texts[start:min(start+maxBatch, len(texts))]
+
+		resp, err := g.Client.Translate(ctx, batch, target, opts)
+		if err != nil {
+			return nil, fmt.Errorf("translate: %v", err)
+		}
+		if len(resp) != len(batch) {
+			return nil, fmt.Errorf("translate returned %d translations for %d texts", len(resp), len(batch))
+		}
+
+		for _, t := range resp {
+			out = append(out, t.Text)
+		}
+	}
+
+	return out, nil
+}
+
+// Noop returns texts untranslated.
+type Noop struct{}
+
+func (Noop) Translate(_ context.Context, texts []string, _ language.Tag) ([]string, error) {
+	return texts, nil
+}
+
+// Dictionary translates from a fixed table keyed by target language and then source text.
+// Texts missing from the table are returned untranslated, so it can be used for offline runs.
+type Dictionary map[string]map[string]string
+
+// LoadDictionary reads a dictionary from a JSON file such as {"en": {"Titre": "Title"}}.
+func LoadDictionary(path string) (Dictionary, error) {
+	b, err := os.ReadFile(path) // #nosec G304 -- path comes from configuration
+	if err != nil {
+		return nil, err
+	}
+
+	var d Dictionary
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse dictionary %s: %w", path, err)
+	}
+	return d, nil
+}
+
+func (d Dictionary) Translate(_ context.Context, texts []string, target language.Tag) ([]string, error) {
+	table := d[target.String()]
+
+	out := make([]string, len(texts))
+	for i, text := range texts {
+		if t, ok := table[text]; ok {
+			out[i] = t
+		} else {
+			out[i] = text
+		}
+	}
+	return out, nil
+}
+
+// Cache persists translations by key.
+type Cache interface {
+	// Get returns the cached translations of the keys that are present.
+	Get(ctx context.Context, keys []string) (map[string]string, error)
+	Set(ctx context.Context, translations map[string]string) error
+}
+
+// ReadOnly uses the translations in Cache without adding any, e.g. for dry runs.
+type ReadOnly struct {
+	Cache Cache
+}
+
+func (r ReadOnly) Get(ctx context.Context, keys []string) (map[string]string, error) {
+	return r.Cache.Get(ctx, keys)
+}
+
+func (ReadOnly) Set(context.Context, map[string]string) error {
+	return nil
+}
+
+// Cached wraps a Translator, only sending texts that are not in the cache.
+type Cached struct {
+	Translator Translator
+	Cache      Cache
+}
+
+func (c *Cached) Translate(ctx context.Context, texts []string, target language.Tag) ([]string, error) {
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = Key(text, target)
+	}
+
+	cached, err := c.Cache.Get(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	if cached == nil {
+		cached = make(map[string]string, len(texts))
+	}
+
+	// translate each missing text once
+	var missing []string
+	seen := make(map[string]bool)
+	for i, text := range texts {
+		if _, ok := cached[keys[i]]; !ok && !seen[text] {
+			seen[text] = true
+			missing = append(missing, text)
+		}
+	}
+
+	if len(missing) > 0 {
+		translated, err := c.Translator.Translate(ctx, missing, target)
+		if err != nil {
+			return nil, err
+		}
+
+		fresh := make(map[string]string, len(missing))
+		for i, text := range missing {
+			fresh[Key(text, target)] = translated[i]
+		}
+		if err := c.Cache.Set(ctx, fresh); err != nil {
+			return nil, err
+		}
+
+		for k, v := range fresh {
+			cached[k] = v
+		}
+	}
+
+	out := make([]string, len(texts))
+	for i := range texts {
+		out[i] = cached[keys[i]]
+	}
+	return out, nil
+}
+
+// Key identifies the translation of text into target.
+func Key(text string, target language.Tag) string {
+	sum := sha256.Sum256([]byte(target.String() + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package translate_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"api/internal/updater/translate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+type countingTranslator struct {
+	calls [][]string
+}
+
+func (c *countingTranslator) Translate(_ context.Context, texts []string, _ language.Tag) ([]string, error) {
+	c.calls = append(c.calls, texts)
+	out := make([]string, len(texts))
+	for i, t := range texts {
+		out[i] = "en:" + t
+	}
+	return out, nil
+}
+
+func TestCached_Translate(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingTranslator{}
+	cache := &translate.FileCache{Path: filepath.Join(t.TempDir(), "cache.json")}
+	c := translate.Cached{Translator: inner, Cache: cache}
+
+	got, err := c.Translate(ctx, []string{"Titre", "Titel", "Titre"}, language.English)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en:Titre", "en:Titel", "en:Titre"}, got)
+
+	got, err = c.Translate(ctx, []string{"Titel", "タイトル"}, language.English)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en:Titel", "en:タイトル"}, got)
+
+	// duplicates and cached texts are only sent once
+	assert.Equal(t, [][]string{{"Titre", "Titel"}, {"タイトル"}}, inner.calls)
+
+	// the cache survives a new instance
+	c = translate.Cached{Translator: translate.Noop{}, Cache: &translate.FileCache{Path: cache.Path}}
+	got, err = c.Translate(ctx, []string{"Titre"}, language.English)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en:Titre"}, got)
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	cache := &translate.FileCache{Path: filepath.Join(t.TempDir(), "cache.json")}
+	require.NoError(t, cache.Set(ctx, map[string]string{translate.Key("Titre", language.English): "Title"}))
+
+	inner := &countingTranslator{}
+	c := translate.Cached{Translator: inner, Cache: translate.ReadOnly{Cache: cache}}
+	got, err := c.Translate(ctx, []string{"Titre", "Titel"}, language.English)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Title", "en:Titel"}, got)
+
+	// the new translation isn't stored
+	stored, err := cache.Get(ctx, []string{translate.Key("Titel", language.English)})
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestDictionary_Translate(t *testing.T) {
+	d := translate.Dictionary{"en": {"Titre": "Title"}}
+
+	got, err := d.Translate(context.Background(), []string{"Titre", "Inconnu"}, language.English)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Title", "Inconnu"}, got)
+}
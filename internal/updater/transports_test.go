@@ -0,0 +1,292 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"api/internal/fetch"
+	"api/internal/updater/annotate"
+	"api/internal/updater/firestore"
+	"api/internal/updater/geocode"
+	imgpkg "api/internal/updater/image"
+	"api/internal/updater/lock"
+	"api/internal/updater/trigger"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore keeps wallpapers and records in memory.
+type memStore struct {
+	mu     sync.Mutex
+	images map[string]imgpkg.Image
+	jobs   map[string]firestore.Job
+	audits []firestore.AnnotationAudit
+}
+
+func newMemStore(images ...imgpkg.Image) *memStore {
+	s := &memStore{images: map[string]imgpkg.Image{}, jobs: map[string]firestore.Job{}}
+	for _, image := range images {
+		s.images[image.ID] = image
+	}
+	return s
+}
+
+func (s *memStore) Get(_ context.Context, id string) (*imgpkg.Image, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	image, ok := s.images[id]
+	if !ok {
+		return nil, nil
+	}
+	return &image, nil
+}
+
+func (s *memStore) GetByAlias(_ context.Context, id string) (*imgpkg.Image, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, image := range s.images {
+		if slices.Contains(image.Aliases, id) {
+			return &image, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) ListHashes(context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hashes := map[string]string{}
+	for id, image := range s.images {
+		if image.PHash != "" {
+			hashes[id] = image.PHash
+		}
+	}
+	return hashes, nil
+}
+
+func (s *memStore) List(_ context.Context, q firestore.ListQuery) ([]imgpkg.Image, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var images []imgpkg.Image
+	for _, image := range s.images {
+		images = append(images, image)
+	}
+	slices.SortFunc(images, func(a, b imgpkg.Image) int { return a.Date - b.Date })
+	if q.Limit > 0 && len(images) > q.Limit {
+		images = images[:q.Limit]
+	}
+	return images, nil
+}
+
+func (s *memStore) Upsert(_ context.Context, img imgpkg.Image) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.images[img.ID] = img
+	return nil
+}
+
+func (s *memStore) UpdateAnnotations(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) UpdatePlaceholders(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) UpdateResolutions(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) UpdateHash(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) UpdateVariants(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) UpdateMirrors(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) UpdateAttribution(ctx context.Context, img imgpkg.Image) error {
+	return s.Upsert(ctx, img)
+}
+
+func (s *memStore) AddPhotographer(context.Context, string, imgpkg.Image) error {
+	return nil
+}
+
+func (s *memStore) SetPhotographers(context.Context, string, []firestore.Photographer) error {
+	return nil
+}
+
+func (s *memStore) GetCheckpoint(context.Context, string, string) (*firestore.Checkpoint, error) {
+	return nil, nil
+}
+
+func (s *memStore) SetCheckpoint(context.Context, string, string, firestore.Checkpoint) error {
+	return nil
+}
+
+func (s *memStore) DeleteCheckpoint(context.Context, string, string) error {
+	return nil
+}
+
+func (s *memStore) AddAudit(_ context.Context, _ string, a firestore.AnnotationAudit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.audits = append(s.audits, a)
+	return nil
+}
+
+func (s *memStore) SetJob(_ context.Context, _ string, job firestore.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *memStore) DeleteJobsBefore(context.Context, string, time.Time) (int, error) {
+	return 0, nil
+}
+
+// finished returns the jobs that have ended.
+func (s *memStore) finished() []firestore.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []firestore.Job
+	for _, job := range s.jobs {
+		if job.EndedAt != nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+const reannotateMessage = `{"version":1,"type":"reannotate","ids":["PresDayDC"]}`
+
+// newTransportUpdater returns an updater over one stored wallpaper, whose annotator tags it with "sky".
+func newTransportUpdater(t *testing.T) (*Updater, *memStore) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("jpeg"))
+	}))
+	t.Cleanup(server.Close)
+
+	s := newMemStore(imgpkg.Image{
+		ID:      "PresDayDC",
+		Date:    20230220,
+		URLBase: server.URL + "/th?id=OHR.PresDayDC_EN-US2054662773",
+		Tags:    map[string]float32{"monument": 0.8},
+	})
+	u := &Updater{
+		annotator:       fakeAnnotator{&annotate.Annotation{Labels: []annotate.Label{{Name: "Sky", Score: 0.9}}}},
+		firestoreClient: s,
+		geocoder:        geocode.Noop{},
+		fetcher:         &fetch.Fetcher{HC: server.Client(), MaxRetries: -1},
+		locker:          lock.NewMemory(),
+		leaseTTL:        time.Minute,
+	}
+	return u, s
+}
+
+// assertReannotated checks that the wallpaper was re-annotated by a single job started by trigger.
+func assertReannotated(t *testing.T, s *memStore, trigger string) {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(s.finished()) > 0 }, 5*time.Second, 10*time.Millisecond)
+
+	jobs := s.finished()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "reannotate", jobs[0].Type)
+	assert.Equal(t, trigger, jobs[0].Trigger)
+	assert.Equal(t, firestore.JobSucceeded, jobs[0].Status)
+	assert.Equal(t, []string{"PresDayDC"}, jobs[0].Updated)
+
+	image, err := s.Get(context.Background(), "PresDayDC")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float32{"sky": 0.9}, image.Tags)
+	assert.Len(t, s.audits, 1)
+}
+
+func TestTransport_Channel(t *testing.T) {
+	u, s := newTransportUpdater(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := trigger.NewChannel(1)
+	require.NoError(t, c.Publish(ctx, []byte(reannotateMessage)))
+	done := make(chan error)
+	go func() { done <- c.Start(ctx, u.Handlers(TriggerCLI).Dispatch) }()
+
+	assertReannotated(t, s, TriggerCLI)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestTransport_PubSub(t *testing.T) {
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+	t.Setenv("PUBSUB_EMULATOR_HOST", srv.Addr)
+
+	u, s := newTransportUpdater(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &trigger.PubSub{ProjectID: "test", TopicID: TopicID, SubID: SubID, Concurrency: 1}
+	done := make(chan error)
+	go func() { done <- p.Start(ctx, u.Handlers(TriggerPubSub).Dispatch) }()
+
+	client, err := pubsub.NewClient(ctx, "test")
+	require.NoError(t, err)
+	defer client.Close()
+	require.Eventually(t, func() bool {
+		ok, err := client.Subscription(SubID).Exists(ctx)
+		return err == nil && ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	topic := client.Topic(TopicID)
+	defer topic.Stop()
+	_, err = topic.Publish(ctx, &pubsub.Message{Data: []byte(reannotateMessage)}).Get(ctx)
+	require.NoError(t, err)
+
+	assertReannotated(t, s, TriggerPubSub)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestTransport_Push(t *testing.T) {
+	u, s := newTransportUpdater(t)
+	p := &trigger.Push{Path: "/push"}
+	server := httptest.NewServer(p.Handler(u.Handlers(TriggerPush).Dispatch))
+	t.Cleanup(server.Close)
+
+	post := func() int {
+		body := `{"message":{"data":"` + base64.StdEncoding.EncodeToString([]byte(reannotateMessage)) + `","messageId":"1"}}`
+		resp, err := http.Post(server.URL, "application/json", bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// another run holds the lease, so Pub/Sub is asked to push the message again later
+	h, ok, err := u.locker.Acquire(context.Background(), updateLease, "", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, post())
+	assert.Empty(t, s.finished())
+
+	require.NoError(t, u.locker.Release(context.Background(), h))
+	assert.Equal(t, http.StatusNoContent, post())
+	assertReannotated(t, s, TriggerPush)
+}
@@ -0,0 +1,42 @@
+package trigger
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+)
+
+// Channel delivers messages published in the same process, e.g. to run the updater without Pub/Sub.
+// Messages that fail are not redelivered.
+type Channel struct {
+	messages chan []byte
+	sent     atomic.Int64
+}
+
+// NewChannel returns a channel buffering up to size messages.
+func NewChannel(size int) *Channel {
+	return &Channel{messages: make(chan []byte, size)}
+}
+
+// Publish queues a message, waiting for room in the buffer until ctx is done.
+func (c *Channel) Publish(ctx context.Context, data []byte) error {
+	select {
+	case c.messages <- data:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Start handles published messages one at a time until ctx is done.
+func (c *Channel) Start(ctx context.Context, h Handler) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case data := <-c.messages:
+			id := strconv.FormatInt(c.sent.Add(1), 10)
+			_ = handle(ctx, h, id, 1, data)
+		}
+	}
+}
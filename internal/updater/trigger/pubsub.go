@@ -0,0 +1,238 @@
+package trigger
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"api/internal/updater/message"
+
+	"google.golang.org/api/option"
+
+	"cloud.google.com/go/pubsub"
+)
+
+// PubSub receives messages from a Pub/Sub subscription, creating the topic and the subscription if they don't exist.
+// The client connects to the Pub/Sub emulator instead if PUBSUB_EMULATOR_HOST is set.
+type PubSub struct {
+	ProjectID string
+	TopicID   string
+	SubID     string
+	Options   []option.ClientOption
+
+	// MaxAttempts is how many times a failing message is delivered before Pub/Sub moves it to DeadLetterTopicID
+	// (5 by default), or, without a dead-letter topic, before it is dropped (0 retries it until it succeeds).
+	// Deliveries failing with message.ErrBusy don't count.
+	MaxAttempts       int
+	DeadLetterTopicID string
+	// MinBackoff and MaxBackoff bound the exponential delay before Pub/Sub redelivers a failed message
+	// (10s and 10m by default).
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Concurrency is how many messages are handled at once, and Streams how many connections pull them.
+	// 0 uses the client library's defaults.
+	Concurrency int
+	Streams     int
+}
+
+// Start handles messages until ctx is done. Messages h fails on are redelivered, unless they are invalid.
+func (p *PubSub) Start(ctx context.Context, h Handler) error {
+	if p.DeadLetterTopicID != "" && p.MaxAttempts != 0 && (p.MaxAttempts < minDeadLetterAttempts || p.MaxAttempts > maxDeadLetterAttempts) {
+		return fmt.Errorf("max attempts must be between %d and %d with a dead-letter topic, got %d", minDeadLetterAttempts, maxDeadLetterAttempts, p.MaxAttempts)
+	}
+
+	projectID := p.ProjectID
+	if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+		log.Printf("using the Pub/Sub emulator at %s", host)
+		if projectID == "" {
+			projectID = emulatorProjectID
+		}
+	}
+
+	pubsubClient, err := pubsub.NewClient(ctx, projectID, p.Options...)
+	if err != nil {
+		return err
+	}
+	defer pubsubClient.Close()
+
+	topic, err := getOrCreateTopic(ctx, pubsubClient, p.TopicID)
+	if err != nil {
+		return err
+	}
+	defer topic.Stop()
+
+	cfg := &pubsub.SubscriptionConfig{
+		Topic:                     topic,
+		EnableExactlyOnceDelivery: true,
+	}
+	if p.DeadLetterTopicID != "" {
+		deadLetter, err := getOrCreateTopic(ctx, pubsubClient, p.DeadLetterTopicID)
+		if err != nil {
+			return err
+		}
+		cfg.DeadLetterPolicy = &pubsub.DeadLetterPolicy{
+			DeadLetterTopic:     deadLetter.String(),
+			MaxDeliveryAttempts: cmp.Or(p.MaxAttempts, minDeadLetterAttempts),
+		}
+	}
+	// without a retry policy, messages that are busy would be redelivered immediately for as long as the other run takes
+	cfg.RetryPolicy = &pubsub.RetryPolicy{
+		MinimumBackoff: cmp.Or(p.MinBackoff, defaultMinBackoff),
+		MaximumBackoff: cmp.Or(p.MaxBackoff, defaultMaxBackoff),
+	}
+
+	sub, err := getOrCreateSub(ctx, pubsubClient, p.SubID, cfg)
+	if err != nil {
+		return err
+	}
+	sub.ReceiveSettings.MaxOutstandingMessages = p.Concurrency
+	sub.ReceiveSettings.NumGoroutines = p.Streams
+
+	attempts := newAttempts()
+	fmt.Println("image updater listening")
+	return sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		// Pub/Sub only counts deliveries for subscriptions with a dead-letter topic
+		attempt := 0
+		if msg.DeliveryAttempt != nil {
+			attempt = *msg.DeliveryAttempt
+		} else {
+			attempt = attempts.add(msg.ID)
+		}
+
+		if err := handle(ctx, h, msg.ID, attempt, msg.Data); err != nil {
+			if errors.Is(err, message.ErrBusy) {
+				p.retryBusy(ctx, topic, msg, attempt, attempts)
+				return
+			}
+			if p.DeadLetterTopicID != "" || p.MaxAttempts == 0 || attempt < p.MaxAttempts {
+				msg.Nack()
+				return
+			}
+			log.Printf("message %s dropped after %d attempts", msg.ID, attempt)
+		}
+		attempts.done(msg.ID)
+		msg.Ack()
+	})
+}
+
+// retryBusy redelivers a message that couldn't be handled until other work finishes, without counting the attempt.
+// Pub/Sub counts it anyway with a dead-letter topic, so a copy is published again instead on the last attempt.
+func (p *PubSub) retryBusy(ctx context.Context, topic *pubsub.Topic, msg *pubsub.Message, attempt int, attempts *attempts) {
+	if msg.DeliveryAttempt == nil {
+		attempts.undo(msg.ID)
+	} else if attempt >= cmp.Or(p.MaxAttempts, minDeadLetterAttempts) {
+		_, err := topic.Publish(ctx, &pubsub.Message{Data: msg.Data, Attributes: msg.Attributes}).Get(ctx)
+		if err == nil {
+			msg.Ack()
+			return
+		}
+		log.Printf("message %s could not be published again: %v", msg.ID, err)
+	}
+	msg.Nack()
+}
+
+// Pub/Sub's bounds on the delivery attempts before dead-lettering, and its default retry policy.
+const (
+	minDeadLetterAttempts = 5
+	maxDeadLetterAttempts = 100
+
+	defaultMinBackoff = 10 * time.Second
+	defaultMaxBackoff = 600 * time.Second
+)
+
+// attempts counts the deliveries of messages to this subscriber until they are acknowledged.
+type attempts struct {
+	mu sync.Mutex
+	n  map[string]int
+}
+
+func newAttempts() *attempts {
+	return &attempts{n: map[string]int{}}
+}
+
+// add records a delivery of the message id, returning how many there have been.
+func (a *attempts) add(id string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.n[id]++
+	return a.n[id]
+}
+
+// undo forgets the last delivery of the message id.
+func (a *attempts) undo(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.n[id]--
+}
+
+// done forgets the message id.
+func (a *attempts) done(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.n, id)
+}
+
+// getOrCreateTopic gets a topic or creates it if it doesn't exist.
+func getOrCreateTopic(ctx context.Context, client *pubsub.Client, topicID string) (*pubsub.Topic, error) {
+	topic := client.Topic(topicID)
+	ok, err := topic.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if topic exists: %v", err)
+	}
+	if !ok {
+		topic, err = client.CreateTopic(ctx, topicID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create topic (%q): %v", topicID, err)
+		}
+	}
+	return topic, nil
+}
+
+// getOrCreateSub gets a subscription or creates it if it doesn't exist.
+// The dead-letter and retry policies of an existing subscription are updated to match cfg.
+func getOrCreateSub(ctx context.Context, client *pubsub.Client, subID string, cfg *pubsub.SubscriptionConfig) (*pubsub.Subscription, error) {
+	sub := client.Subscription(subID)
+	ok, err := sub.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if subscription exists: %v", err)
+	}
+	if !ok {
+		sub, err = client.CreateSubscription(ctx, subID, *cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create subscription (%q): %v", subID, err)
+		}
+		return sub, nil
+	}
+
+	current, err := sub.Config(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription (%q): %v", subID, err)
+	}
+	var update pubsub.SubscriptionConfigToUpdate
+	if !reflect.DeepEqual(current.DeadLetterPolicy, cfg.DeadLetterPolicy) {
+		// the zero value removes the policy
+		update.DeadLetterPolicy = &pubsub.DeadLetterPolicy{}
+		if cfg.DeadLetterPolicy != nil {
+			update.DeadLetterPolicy = cfg.DeadLetterPolicy
+		}
+	}
+	if !reflect.DeepEqual(current.RetryPolicy, cfg.RetryPolicy) {
+		update.RetryPolicy = &pubsub.RetryPolicy{}
+		if cfg.RetryPolicy != nil {
+			update.RetryPolicy = cfg.RetryPolicy
+		}
+	}
+	if update.DeadLetterPolicy == nil && update.RetryPolicy == nil {
+		return sub, nil
+	}
+	if _, err := sub.Update(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to update subscription (%q): %v", subID, err)
+	}
+	return sub, nil
+}
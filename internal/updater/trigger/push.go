@@ -0,0 +1,125 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/idtoken"
+)
+
+// Push receives the messages a Pub/Sub push subscription posts to Path, served on Addr.
+// Requests must carry an OIDC token for Audience, signed by Google for the service account Email if set.
+// Verification is disabled if Audience is empty, e.g. for the emulator.
+type Push struct {
+	Addr     string
+	Path     string
+	Audience string
+	Email    string
+
+	validate func(ctx context.Context, token, audience string) (*idtoken.Payload, error)
+}
+
+// pushRequest is the body of a Pub/Sub push request.
+type pushRequest struct {
+	Message struct {
+		Data       []byte            `json:"data"` // Base64 in JSON
+		MessageID  string            `json:"messageId"`
+		Attributes map[string]string `json:"attributes"`
+	} `json:"message"`
+	Subscription string `json:"subscription"`
+	// DeliveryAttempt is only sent if the subscription has a dead-letter topic.
+	DeliveryAttempt int `json:"deliveryAttempt"`
+}
+
+// Start serves the push endpoint until ctx is done.
+func (p *Push) Start(ctx context.Context, h Handler) error {
+	if p.Audience == "" {
+		log.Println("warning: push requests are not authenticated")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST "+p.Path, p.Handler(h))
+	srv := &http.Server{
+		Addr:        p.Addr,
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("image updater listening for push requests on %s%s", p.Addr, p.Path)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// Handler returns the push endpoint handling messages with h. Pub/Sub redelivers a message unless it responds with 2xx,
+// backing off and dead-lettering it as configured on the push subscription.
+func (p *Push) Handler(h Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := p.verify(r); err != nil {
+			log.Printf("push request rejected: %v", err)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		var req pushRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "invalid push request", http.StatusBadRequest)
+			return
+		}
+
+		if err := handle(r.Context(), h, req.Message.MessageID, req.DeliveryAttempt, req.Message.Data); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// verify checks the OIDC token Pub/Sub signs push requests with.
+func (p *Push) verify(r *http.Request) error {
+	if p.Audience == "" {
+		return nil
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return errors.New("missing bearer token")
+	}
+
+	validate := p.validate
+	if validate == nil {
+		validate = idtoken.Validate
+	}
+	payload, err := validate(r.Context(), token, p.Audience)
+	if err != nil {
+		return err
+	}
+
+	if p.Email != "" {
+		email, _ := payload.Claims["email"].(string)
+		verified, _ := payload.Claims["email_verified"].(bool)
+		if email != p.Email || !verified {
+			return fmt.Errorf("token is for %q, not %q", email, p.Email)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"api/internal/updater/message"
+)
+
+// emulatorProjectID is used with the Pub/Sub emulator when no project is configured.
+const emulatorProjectID = "local"
+
+// Handler handles the body of a message, see message.Registry.Dispatch.
+// Messages it fails on with an error wrapping message.ErrInvalid are dropped rather than retried,
+// and those wrapping message.ErrBusy are retried without counting toward the attempts.
+type Handler func(ctx context.Context, data []byte) error
+
+// Transport delivers messages asking the updater to run to a handler.
+type Transport interface {
+	// Start delivers messages to h until ctx is done.
+	Start(ctx context.Context, h Handler) error
+}
+
+// handle calls h with a message delivered for the attempt-th time (0 if unknown),
+// returning an error only if the message should be redelivered.
+func handle(ctx context.Context, h Handler, id string, attempt int, data []byte) error {
+	err := h(ctx, data)
+	if err == nil {
+		return nil
+	}
+
+	// retrying can't fix a malformed message
+	if errors.Is(err, message.ErrInvalid) {
+		log.Printf("message %s rejected: %v", id, err)
+		return nil
+	}
+	if errors.Is(err, message.ErrBusy) {
+		log.Printf("message %s deferred: %v", id, err)
+		return err
+	}
+	if attempt > 0 {
+		log.Printf("message %s processing failed (attempt %d): %v", id, attempt, err)
+	} else {
+		log.Printf("message %s processing failed: %v", id, err)
+	}
+	return err
+}
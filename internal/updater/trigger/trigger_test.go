@@ -0,0 +1,370 @@
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"api/internal/updater/message"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/idtoken"
+)
+
+// fakeUpdater records the messages dispatched to it, failing refreshes of "Broken",
+// and refreshes of "Busy" while busy is positive.
+type fakeUpdater struct {
+	mu       sync.Mutex
+	messages []message.Message
+	busy     int
+	handled  chan struct{}
+}
+
+func newFakeUpdater() *fakeUpdater {
+	return &fakeUpdater{handled: make(chan struct{}, 20)}
+}
+
+func (f *fakeUpdater) handler() Handler {
+	r := message.NewRegistry()
+	record := func(_ context.Context, m message.Message) error {
+		f.mu.Lock()
+		f.messages = append(f.messages, m)
+		busy := m.ID == "Busy" && f.busy > 0
+		if busy {
+			f.busy--
+		}
+		f.mu.Unlock()
+		f.handled <- struct{}{}
+		if m.ID == "Broken" {
+			return errors.New("failed to download image")
+		}
+		if busy {
+			return fmt.Errorf("%w: another update is running", message.ErrBusy)
+		}
+		return nil
+	}
+	r.Handle(message.TypeUpdate, record)
+	r.Handle(message.TypeRefresh, record)
+	return r.Dispatch
+}
+
+func (f *fakeUpdater) wait(t *testing.T, n int) []message.Message {
+	t.Helper()
+	for range n {
+		select {
+		case <-f.handled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a message")
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.messages
+}
+
+func TestChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeUpdater()
+	c := NewChannel(3)
+	require.NoError(t, c.Publish(ctx, nil))
+	require.NoError(t, c.Publish(ctx, []byte(`{"version":2,"type":"update"}`)))
+	require.NoError(t, c.Publish(ctx, []byte(`{"version":1,"type":"refresh","id":"PresDayDC"}`)))
+
+	done := make(chan error)
+	go func() { done <- c.Start(ctx, f.handler()) }()
+
+	// the invalid message never reaches the updater
+	assert.Equal(t, []message.Message{
+		{Version: 1, Type: message.TypeUpdate},
+		{Version: 1, Type: message.TypeRefresh, ID: "PresDayDC"},
+	}, f.wait(t, 2))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// startEmulator points Pub/Sub clients at a fake server for the test.
+func startEmulator(t *testing.T) *pubsub.Client {
+	t.Helper()
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+	t.Setenv("PUBSUB_EMULATOR_HOST", srv.Addr)
+
+	client, err := pubsub.NewClient(context.Background(), emulatorProjectID)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// startPubSub starts p, returning the topic to publish to once its subscription exists.
+func startPubSub(ctx context.Context, t *testing.T, client *pubsub.Client, p *PubSub, h Handler) (*pubsub.Topic, <-chan error) {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- p.Start(ctx, h) }()
+
+	// the topic and subscription are created when the subscriber starts
+	require.Eventually(t, func() bool {
+		ok, err := client.Subscription(p.SubID).Exists(ctx)
+		return err == nil && ok
+	}, 5*time.Second, 10*time.Millisecond)
+	topic := client.Topic(p.TopicID)
+	t.Cleanup(topic.Stop)
+	return topic, done
+}
+
+func publish(ctx context.Context, t *testing.T, topic *pubsub.Topic, data string) {
+	t.Helper()
+	_, err := topic.Publish(ctx, &pubsub.Message{Data: []byte(data)}).Get(ctx)
+	require.NoError(t, err)
+}
+
+func TestPubSub_Emulator(t *testing.T) {
+	client := startEmulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeUpdater()
+	p := &PubSub{TopicID: "update-wallpapers", SubID: "update-wallpapers-sub", Concurrency: 1}
+	topic, done := startPubSub(ctx, t, client, p, f.handler())
+	publish(ctx, t, topic, `{"version":1,"type":"refresh","id":"PresDayDC"}`)
+
+	assert.Equal(t, []message.Message{{Version: 1, Type: message.TypeRefresh, ID: "PresDayDC"}}, f.wait(t, 1))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestPubSub_DeadLetter(t *testing.T) {
+	client := startEmulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deadLetter, err := client.CreateTopic(ctx, "update-wallpapers-dead-letter")
+	require.NoError(t, err)
+	dead, err := client.CreateSubscription(ctx, "dead-letter-sub", pubsub.SubscriptionConfig{Topic: deadLetter})
+	require.NoError(t, err)
+
+	f := newFakeUpdater()
+	p := &PubSub{
+		TopicID:           "update-wallpapers",
+		SubID:             "update-wallpapers-sub",
+		MaxAttempts:       5,
+		DeadLetterTopicID: "update-wallpapers-dead-letter",
+	}
+	topic, done := startPubSub(ctx, t, client, p, f.handler())
+	publish(ctx, t, topic, `{"version":1,"type":"refresh","id":"Broken"}`)
+
+	assert.Len(t, f.wait(t, 5), 5)
+
+	receiveCtx, stop := context.WithTimeout(ctx, 5*time.Second)
+	defer stop()
+	var got []byte
+	require.NoError(t, dead.Receive(receiveCtx, func(_ context.Context, msg *pubsub.Message) {
+		got = msg.Data
+		msg.Ack()
+		stop()
+	}))
+	assert.JSONEq(t, `{"version":1,"type":"refresh","id":"Broken"}`, string(got))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestPubSub_MaxAttempts(t *testing.T) {
+	client := startEmulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeUpdater()
+	p := &PubSub{TopicID: "update-wallpapers", SubID: "update-wallpapers-sub", MaxAttempts: 3}
+	topic, done := startPubSub(ctx, t, client, p, f.handler())
+	publish(ctx, t, topic, `{"version":1,"type":"refresh","id":"Broken"}`)
+
+	assert.Len(t, f.wait(t, 3), 3)
+	// dropped rather than retried again
+	select {
+	case <-f.handled:
+		t.Fatal("message delivered after the last attempt")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestPubSub_Busy(t *testing.T) {
+	client := startEmulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeUpdater()
+	f.busy = 4
+	p := &PubSub{TopicID: "update-wallpapers", SubID: "update-wallpapers-sub", MaxAttempts: 2}
+	topic, done := startPubSub(ctx, t, client, p, f.handler())
+	publish(ctx, t, topic, `{"version":1,"type":"refresh","id":"Busy"}`)
+
+	// retried past the last attempt until it is handled, then acknowledged
+	assert.Len(t, f.wait(t, 5), 5)
+	select {
+	case <-f.handled:
+		t.Fatal("message delivered after it was handled")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestPubSub_DefaultRetryPolicy(t *testing.T) {
+	client := startEmulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &PubSub{TopicID: "update-wallpapers", SubID: "update-wallpapers-sub"}
+	_, done := startPubSub(ctx, t, client, p, newFakeUpdater().handler())
+
+	// busy messages back off even without a configured retry policy
+	cfg, err := client.Subscription(p.SubID).Config(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &pubsub.RetryPolicy{MinimumBackoff: defaultMinBackoff, MaximumBackoff: defaultMaxBackoff}, cfg.RetryPolicy)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestPubSub_BusyDeadLetter(t *testing.T) {
+	client := startEmulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deadLetter, err := client.CreateTopic(ctx, "update-wallpapers-dead-letter")
+	require.NoError(t, err)
+	dead, err := client.CreateSubscription(ctx, "dead-letter-sub", pubsub.SubscriptionConfig{Topic: deadLetter})
+	require.NoError(t, err)
+
+	f := newFakeUpdater()
+	f.busy = 7
+	p := &PubSub{
+		TopicID:           "update-wallpapers",
+		SubID:             "update-wallpapers-sub",
+		MaxAttempts:       5,
+		DeadLetterTopicID: "update-wallpapers-dead-letter",
+	}
+	topic, done := startPubSub(ctx, t, client, p, f.handler())
+	publish(ctx, t, topic, `{"version":1,"type":"refresh","id":"Busy"}`)
+
+	// published again rather than dead-lettered on the last attempt
+	assert.Len(t, f.wait(t, 8), 8)
+
+	receiveCtx, stop := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer stop()
+	require.NoError(t, dead.Receive(receiveCtx, func(_ context.Context, msg *pubsub.Message) {
+		t.Errorf("message dead-lettered: %s", msg.Data)
+		msg.Ack()
+	}))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestGetOrCreateSub_UpdatesPolicies(t *testing.T) {
+	client := startEmulator(t)
+	ctx := context.Background()
+
+	topic, err := client.CreateTopic(ctx, "update-wallpapers")
+	require.NoError(t, err)
+	deadLetter, err := client.CreateTopic(ctx, "update-wallpapers-dead-letter")
+	require.NoError(t, err)
+	_, err = client.CreateSubscription(ctx, "update-wallpapers-sub", pubsub.SubscriptionConfig{Topic: topic})
+	require.NoError(t, err)
+
+	cfg := &pubsub.SubscriptionConfig{
+		Topic:            topic,
+		DeadLetterPolicy: &pubsub.DeadLetterPolicy{DeadLetterTopic: deadLetter.String(), MaxDeliveryAttempts: 10},
+		RetryPolicy:      &pubsub.RetryPolicy{MinimumBackoff: 10 * time.Second, MaximumBackoff: 5 * time.Minute},
+	}
+	sub, err := getOrCreateSub(ctx, client, "update-wallpapers-sub", cfg)
+	require.NoError(t, err)
+
+	got, err := sub.Config(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.DeadLetterPolicy, got.DeadLetterPolicy)
+	assert.Equal(t, cfg.RetryPolicy, got.RetryPolicy)
+
+	// removed again
+	sub, err = getOrCreateSub(ctx, client, "update-wallpapers-sub", &pubsub.SubscriptionConfig{Topic: topic})
+	require.NoError(t, err)
+	got, err = sub.Config(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, got.DeadLetterPolicy)
+	assert.Nil(t, got.RetryPolicy)
+}
+
+func pushBody(data string) []byte {
+	return []byte(`{"message":{"data":"` + base64.StdEncoding.EncodeToString([]byte(data)) + `","messageId":"1"},"subscription":"projects/p/subscriptions/s"}`)
+}
+
+func TestPush(t *testing.T) {
+	f := newFakeUpdater()
+	p := &Push{
+		Path:     "/push",
+		Audience: "https://updater.example.com/push",
+		Email:    "pubsub@example.iam.gserviceaccount.com",
+		validate: func(_ context.Context, token, audience string) (*idtoken.Payload, error) {
+			if audience != "https://updater.example.com/push" {
+				return nil, errors.New("wrong audience")
+			}
+			switch token {
+			case "valid":
+				return &idtoken.Payload{Claims: map[string]any{"email": "pubsub@example.iam.gserviceaccount.com", "email_verified": true}}, nil
+			case "other":
+				return &idtoken.Payload{Claims: map[string]any{"email": "someone@example.com", "email_verified": true}}, nil
+			}
+			return nil, errors.New("invalid token")
+		},
+	}
+	server := httptest.NewServer(p.Handler(f.handler()))
+	t.Cleanup(server.Close)
+
+	post := func(token string, body []byte) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	refresh := pushBody(`{"version":1,"type":"refresh","id":"PresDayDC"}`)
+	assert.Equal(t, http.StatusUnauthorized, post("", refresh))
+	assert.Equal(t, http.StatusUnauthorized, post("forged", refresh))
+	assert.Equal(t, http.StatusUnauthorized, post("other", refresh))
+
+	assert.Equal(t, http.StatusNoContent, post("valid", refresh))
+	// acknowledged so that Pub/Sub doesn't retry it
+	assert.Equal(t, http.StatusNoContent, post("valid", pushBody(`{"version":9}`)))
+	// retried by Pub/Sub
+	assert.Equal(t, http.StatusInternalServerError, post("valid", pushBody(`{"version":1,"type":"refresh","id":"Broken"}`)))
+	assert.Equal(t, http.StatusBadRequest, post("valid", []byte("{")))
+
+	assert.Equal(t, []message.Message{
+		{Version: 1, Type: message.TypeRefresh, ID: "PresDayDC"},
+		{Version: 1, Type: message.TypeRefresh, ID: "Broken"},
+	}, f.wait(t, 2))
+}
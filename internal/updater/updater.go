@@ -2,32 +2,58 @@ package updater
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
+	"log"
+	"maps"
 	"net/http"
 	"os"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"api/internal/collections"
+	"api/internal/fetch"
+	"api/internal/geo"
+	"api/internal/updater/annotate"
 	"api/internal/updater/bing"
+	"api/internal/updater/blob"
 	"api/internal/updater/firestore"
+	"api/internal/updater/geocode"
 	imgpkg "api/internal/updater/image"
-	"cloud.google.com/go/translate"
-	"cloud.google.com/go/vision/v2/apiv1"
-	"cloud.google.com/go/vision/v2/apiv1/visionpb"
+	"api/internal/updater/lock"
+	"api/internal/updater/message"
+	"api/internal/updater/phash"
+	"api/internal/updater/probe"
+	"api/internal/updater/translate"
 
-	"firebase.google.com/go"
+	gfirestore "cloud.google.com/go/firestore"
 
 	"golang.org/x/text/language"
 )
 
 const (
-	TopicID             = "update-wallpapers-v2"
-	SubID               = "update-wallpapers-v2-sub"
-	firestoreCollection = "BingWallpapers"
-	bingURL             = "https://www.bing.com"
+	TopicID                = "update-wallpapers-v2"
+	SubID                  = "update-wallpapers-v2-sub"
+	translationsCollection = "Translations"
+	bingURL                = "https://www.bing.com"
+
+	defaultRequiredResolution = "1920x1200"
+	defaultDuplicateDistance  = 6
+)
+
+// ResolutionPolicy decides what happens to a new image without the required resolution.
+type ResolutionPolicy string
+
+const (
+	// ResolutionReject skips the image.
+	ResolutionReject ResolutionPolicy = "reject"
+	// ResolutionWarn logs a warning and adds the image anyway.
+	ResolutionWarn ResolutionPolicy = "warn"
+	// ResolutionIgnore adds the image without checking.
+	ResolutionIgnore ResolutionPolicy = "ignore"
 )
 
 var (
@@ -51,257 +77,798 @@ var (
 	}
 )
 
-func New() (*Updater, error) {
-	ctx := context.Background()
-	conf := &firebase.Config{ProjectID: os.Getenv("PROJECT_ID")}
+// New configures an updater from the environment, storing images with the given Firestore client.
+func New(ctx context.Context, fs *gfirestore.Client) (*Updater, error) {
+	fetcher, err := newFetcher()
+	if err != nil {
+		return nil, err
+	}
 
-	httpClient := &http.Client{Timeout: time.Second * 15}
+	imageClient := &bing.Client{BaseURL: bingURL, Fetcher: fetcher}
+	if v := os.Getenv("BING_ENDPOINTS"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			endpoint, ok := bing.Endpoints[name]
+			if !ok {
+				return nil, fmt.Errorf("invalid bing endpoint %q", name)
+			}
+			imageClient.Endpoints = append(imageClient.Endpoints, endpoint)
+		}
+	}
 
-	imageClient := &bing.Client{BaseURL: bingURL, HC: httpClient}
+	firestoreClient := firestore.NewClient(collections.Wallpapers, fs)
 
-	firebaseClient, err := firebase.NewApp(ctx, conf)
+	annotator, err := newAnnotator(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	firestoreClient, err := firestore.NewClient(ctx, firestoreCollection, firebaseClient)
+	translator, err := newTranslator(ctx, firestoreClient)
 	if err != nil {
 		return nil, err
 	}
 
-	annoClient, err := vision.NewImageAnnotatorClient(ctx)
+	geocoder, err := newGeocoder()
 	if err != nil {
 		return nil, err
 	}
 
-	translateClient, err := translate.NewClient(ctx)
+	blobs, err := newBlobStore(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	requiredResolution := os.Getenv("REQUIRED_RESOLUTION")
+	if requiredResolution == "" {
+		requiredResolution = defaultRequiredResolution
+	}
+
+	resolutionPolicy := ResolutionPolicy(os.Getenv("RESOLUTION_POLICY"))
+	switch resolutionPolicy {
+	case "":
+		resolutionPolicy = ResolutionReject
+	case ResolutionReject, ResolutionWarn, ResolutionIgnore:
+	default:
+		return nil, fmt.Errorf("invalid resolution policy %q", resolutionPolicy)
+	}
+
+	duplicatePolicy := DuplicatePolicy(os.Getenv("DUPLICATE_POLICY"))
+	switch duplicatePolicy {
+	case "":
+		duplicatePolicy = DuplicateFlag
+	case DuplicateFlag, DuplicateMerge:
+	default:
+		return nil, fmt.Errorf("invalid duplicate policy %q", duplicatePolicy)
+	}
+
+	locker, err := newLocker(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	leaseTTL, err := newLeaseTTL()
+	if err != nil {
+		return nil, err
+	}
+
+	jobRetention, err := newJobRetention()
+	if err != nil {
+		return nil, err
+	}
+
+	duplicateDistance := defaultDuplicateDistance
+	if v := os.Getenv("DUPLICATE_DISTANCE"); v != "" {
+		duplicateDistance, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duplicate distance %q: %w", v, err)
+		}
+	}
+
 	return &Updater{
-		annoClient:      annoClient,
-		firestoreClient: firestoreClient,
-		httpClient:      httpClient,
-		imageClient:     imageClient,
-		translateClient: translateClient,
+		annotator:          annotator,
+		blobs:              blobs,
+		firestoreClient:    firestoreClient,
+		geocoder:           geocoder,
+		fetcher:            fetcher,
+		imageClient:        imageClient,
+		prober:             &probe.Prober{Fetcher: fetcher},
+		translator:         translator,
+		requiredResolution: requiredResolution,
+		resolutionPolicy:   resolutionPolicy,
+		duplicatePolicy:    duplicatePolicy,
+		duplicateDistance:  duplicateDistance,
+		jobRetention:       jobRetention,
+		locker:             locker,
+		leaseTTL:           leaseTTL,
 	}, nil
 }
 
 type Updater struct {
-	annoClient      *vision.ImageAnnotatorClient
-	firestoreClient *firestore.Client
-	httpClient      *http.Client
+	annotator       annotate.Annotator
+	blobs           blob.Store // nil if images aren't mirrored
+	firestoreClient store
+	geocoder        geocode.Geocoder
+	fetcher         *fetch.Fetcher
 	imageClient     *bing.Client
-	translateClient *translate.Client
+	prober          *probe.Prober
+	translator      translate.Translator
+
+	requiredResolution string
+	resolutionPolicy   ResolutionPolicy
+	duplicatePolicy    DuplicatePolicy
+	duplicateDistance  int
+	jobRetention       time.Duration // Zero keeps job records forever
+	locker             lock.Locker   // nil if runs may overlap
+	leaseTTL           time.Duration
 }
 
-func (u *Updater) Update(ctx context.Context) error {
+// UpdateMarkets fetches the latest wallpapers of markets, or all markets if empty, and stores what changed.
+func (u *Updater) UpdateMarkets(ctx context.Context, markets []string, res *Result) error {
 	fmt.Println("updating images")
 
-	var updatedImages []string
-	images := make(map[string]imgpkg.Image)
+	if len(markets) == 0 {
+		markets = append(ENMarkets, nonENMarkets...)
+	}
 
-	if err := u.fetchAndDedupeImages(ctx, append(ENMarkets, nonENMarkets...), images); err != nil {
+	images := make(map[string]imgpkg.Image)
+	if err := u.fetchAndDedupeImages(ctx, markets, images, res.Markets); err != nil {
 		return err
 	}
 
+	return u.apply(ctx, images, res)
+}
+
+// apply plans, enriches and stores fetched images, recording what happened to each in res.
+// An image that fails is recorded and skipped, so that the others are still stored.
+func (u *Updater) apply(ctx context.Context, images map[string]imgpkg.Image, res *Result) error {
+	var updatedImages []string
+	dups := u.newDuplicates()
+
 	// for each wallpaper, check if exists in db
 	fmt.Printf("%d images found\n", len(images))
+	var steps []step
 	for _, image := range images {
-		existingImage, err := u.firestoreClient.Get(ctx, image.ID)
+		s, err := u.plan(ctx, image, dups, true)
 		if err != nil {
-			return err
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			res.fail(image.ID, err)
+			continue
 		}
 
-		// if exists, check if we need to update
-		if existingImage != nil {
-			// Check if we need to update: either missing urlBase OR upgrading to English
-			needsURLBaseUpdate := existingImage.URLBase == ""
-			needsMarketUpgrade := slices.Contains(nonENMarkets, existingImage.Market) && slices.Contains(ENMarkets, image.Market)
+		if s.change == changeNone || s.change == changeRejected {
+			continue
+		}
+		steps = append(steps, s)
+	}
+	steps = foldDuplicates(steps)
+
+	if err := u.translateNew(ctx, u.translator, steps); err != nil {
+		return err
+	}
 
-			if !needsURLBaseUpdate && !needsMarketUpgrade {
+	located := steps[:0]
+	for _, s := range steps {
+		if s.doc.Geohash == "" {
+			if err := u.locate(ctx, &s.doc); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				res.fail(s.id, err)
 				continue
 			}
+		}
+		located = append(located, s)
+	}
+	steps = located
 
-			// maintain old date
-			image.Date = existingImage.Date
+	for i := range steps {
+		if _, err := u.measure(ctx, &steps[i].doc); err != nil {
+			return err
+		}
+		if _, err := u.mirror(ctx, &steps[i].doc); err != nil {
+			return err
+		}
+	}
 
-			// If only updating urlBase (not upgrading market), preserve existing fields
-			if needsURLBaseUpdate && !needsMarketUpgrade {
-				existingImage.URLBase = image.URLBase
-				_, err = u.firestoreClient.Upsert(ctx, *existingImage)
-			} else {
-				// Full update (market upgrade case)
-				_, err = u.firestoreClient.Upsert(ctx, image)
+	for _, s := range steps {
+		if err := u.firestoreClient.Upsert(ctx, s.doc); err != nil {
+			if ctx.Err() != nil || errors.Is(err, lock.ErrLost) {
+				return err
 			}
+			res.fail(s.id, err)
+			continue
+		}
+		updatedImages = append(updatedImages, s.id)
+		if s.change == changeNew {
+			res.New = append(res.New, s.id)
+		} else {
+			res.Updated = append(res.Updated, s.id)
+		}
 
-			if err != nil {
-				return err
+		if s.change == changeNew && s.doc.PhotographerSlug != "" {
+			// the image is stored, so failing it would keep a retry from counting it
+			if err := u.firestoreClient.AddPhotographer(ctx, collections.Photographers, s.doc); err != nil {
+				log.Printf("warning: failed to count photographer of %s: %v", s.id, err)
 			}
-			updatedImages = append(updatedImages, image.ID)
+		}
+	}
 
-			continue
+	fmt.Printf("%d images updated: %s\n", len(updatedImages), strings.Join(updatedImages, ", "))
+	if len(res.Failed) > 0 {
+		fmt.Printf("%d images failed\n", len(res.Failed))
+	}
+	return nil
+}
+
+// step is the outcome of planning a fetched image.
+type step struct {
+	id       string // fetched image ID
+	market   string // fetched image market
+	change   change
+	existing *imgpkg.Image // stored document that doc replaces, if any
+	doc      imgpkg.Image  // document to write
+}
+
+// plan works out what Update should write for a fetched image.
+// When enrich is false new images are not downloaded, so they are neither annotated nor checked for near-duplicates.
+func (u *Updater) plan(ctx context.Context, image imgpkg.Image, dups *duplicates, enrich bool) (step, error) {
+	s, err := u.planImage(ctx, image, dups, enrich)
+	s.id, s.market = image.ID, image.Market
+	return s, err
+}
+
+func (u *Updater) planImage(ctx context.Context, image imgpkg.Image, dups *duplicates, enrich bool) (step, error) {
+	existingImage, err := u.firestoreClient.Get(ctx, image.ID)
+	if err != nil {
+		return step{}, err
+	}
+
+	if existingImage == nil {
+		// skip near-duplicates that were already merged into another image
+		original, err := u.firestoreClient.GetByAlias(ctx, image.ID)
+		if err != nil {
+			return step{}, err
+		}
+		if original != nil {
+			return step{change: changeNone, existing: original, doc: *original}, nil
+		}
+	}
+
+	if err := u.probeResolutions(ctx, &image); err != nil {
+		return step{}, err
+	}
+
+	c := classify(existingImage, image)
+	if c == changeContent && enrich {
+		fmt.Printf("%s image content changed\n", image.ID)
+
+		doc := merge(existingImage, image, c)
+		if err := u.refresh(ctx, &doc); err != nil {
+			return step{}, err
 		}
+		return step{change: c, existing: existingImage, doc: doc}, nil
+	}
+	if c != changeNew {
+		return step{change: c, existing: existingImage, doc: merge(existingImage, image, c)}, nil
+	}
+
+	fmt.Printf("%s new wallpaper found\n", image.ID)
+
+	if !u.acceptResolution(image) {
+		return step{change: changeRejected, doc: image}, nil
+	}
+
+	if !enrich {
+		return step{change: changeNew, doc: image}, nil
+	}
+
+	imgBytes, err := u.download(ctx, image.URL())
+	if err != nil {
+		return step{}, err
+	}
+
+	hash, err := phash.FromBytes(imgBytes)
+	if err != nil {
+		return step{}, err
+	}
+	image.PHash = phash.Format(hash)
+
+	if err := setPlaceholders(&image, imgBytes); err != nil {
+		return step{}, err
+	}
+
+	original, err := dups.find(ctx, image.ID, hash)
+	if err != nil {
+		return step{}, err
+	}
+	if original != nil {
+		fmt.Printf("%s is a near-duplicate of %s\n", image.ID, original.ID)
+
+		if u.duplicatePolicy == DuplicateMerge {
+			doc := mergeDuplicate(*original, image)
+			dups.merged(doc)
+			return step{change: changeDuplicate, existing: original, doc: doc}, nil
+		}
+		image.DuplicateOf = original.ID
+	}
+
+	if err := u.enrich(ctx, &image, imgBytes); err != nil {
+		return step{}, err
+	}
+
+	dups.add(image, hash)
+
+	return step{change: changeNew, doc: image}, nil
+}
+
+// refresh hashes, annotates and renders placeholders of an image again after Bing replaced it.
+// Its location is cleared so that it is found again from the new landmarks,
+// and its mirrors and variants so that they are copied and measured again.
+func (u *Updater) refresh(ctx context.Context, image *imgpkg.Image) error {
+	imgBytes, err := u.download(ctx, image.URL())
+	if err != nil {
+		return err
+	}
+
+	hash, err := phash.FromBytes(imgBytes)
+	if err != nil {
+		return err
+	}
+	image.PHash = phash.Format(hash)
+
+	if err := setPlaceholders(image, imgBytes); err != nil {
+		return err
+	}
+
+	image.Tags = make(map[string]float32)
+	image.TagsOrdered = nil
+	image.Colors = nil
+	if err := u.enrich(ctx, image, imgBytes); err != nil {
+		return err
+	}
+
+	image.Geohash = ""
+	image.Mirrors = nil
+	image.Variants = nil
+	return nil
+}
+
+// Refresh downloads a stored image again to hash, annotate and render placeholders of it,
+// then locates, measures and mirrors it again, as when Bing replaces an image.
+func (u *Updater) Refresh(ctx context.Context, id string, res *Result) error {
+	image, err := u.firestoreClient.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if image == nil {
+		return fmt.Errorf("%w: wallpaper %s not found", message.ErrInvalid, id)
+	}
+
+	doc := *image
+	if err := u.probeResolutions(ctx, &doc); err != nil {
+		return err
+	}
+	if err := u.refresh(ctx, &doc); err != nil {
+		return err
+	}
+	if err := u.locate(ctx, &doc); err != nil {
+		return err
+	}
+	if _, err := u.measure(ctx, &doc); err != nil {
+		return err
+	}
+	if _, err := u.mirror(ctx, &doc); err != nil {
+		return err
+	}
+
+	if err := u.firestoreClient.Upsert(ctx, doc); err != nil {
+		return err
+	}
+	res.Updated = append(res.Updated, id)
+	fmt.Printf("%s refreshed\n", id)
+	return nil
+}
+
+// change describes what Update does with a fetched image.
+type change int
 
-		fmt.Printf("%s new wallpaper found\n", image.ID)
+const (
+	changeNone change = iota
+	changeNew
+	changeURLBase
+	changeMarket
+	changeResolutions
+	changeAppearances
+	changeLocalized
+	changeRejected
+	changeDuplicate
+	changeContent  // Bing replaced the image, so it is hashed and annotated again
+	changeMetadata // Bing's extra metadata is missing from the stored document
+)
+
+// classify compares a fetched image with the stored one, which is nil if it doesn't exist yet.
+func classify(existing *imgpkg.Image, image imgpkg.Image) change {
+	if existing == nil {
+		return changeNew
+	}
 
-		// translate title if not english
-		if slices.Contains(nonENMarkets, image.Market) {
-			translatedTitle, err := u.translateText(ctx, image.Title)
+	// upgrading to English takes priority, since it rewrites the whole document
+	if slices.Contains(nonENMarkets, existing.Market) && slices.Contains(ENMarkets, image.Market) {
+		return changeMarket
+	}
+
+	if existing.URLBase == "" {
+		return changeURLBase
+	}
+
+	// hashes are only compared within a market, since each market may get its own crop
+	if existing.Hsh != "" && image.Hsh != "" && existing.Hsh != image.Hsh && existing.Market == image.Market {
+		return changeContent
+	}
+
+	if !slices.Equal(existing.Resolutions, image.Resolutions) {
+		return changeResolutions
+	}
+
+	if len(mergeAppearances(history(*existing), image.Appearances)) != len(existing.Appearances) {
+		return changeAppearances
+	}
+
+	if !maps.Equal(mergeLocalized(localizedHistory(*existing), image.Localized), existing.Localized) {
+		return changeLocalized
+	}
+
+	if existing.Hsh == "" && image.Hsh != "" {
+		return changeMetadata
+	}
+
+	return changeNone
+}
+
+// merge returns the document that is written for the given change.
+func merge(existing *imgpkg.Image, image imgpkg.Image, c change) imgpkg.Image {
+	if existing == nil {
+		return image
+	}
+
+	var out imgpkg.Image
+	switch c {
+	case changeMarket:
+		// full update, but maintain old date and duplicate tracking
+		out = image
+		out.Date = existing.Date
+		out.PHash = existing.PHash
+		out.BlurHash, out.LQIP = existing.BlurHash, existing.LQIP
+		out.Aliases = existing.Aliases
+		out.DuplicateOf = existing.DuplicateOf
+		out.Mirrors = existing.Mirrors
+		out.Variants = existing.Variants
+		// the image is the same, so keep what was found from its content rather than annotating it again
+		out.Tags, out.TagsOrdered, out.Colors = existing.Tags, existing.TagsOrdered, existing.Colors
+		out.Landmarks, out.LandmarkSlugs = existing.Landmarks, existing.LandmarkSlugs
+		out.WebEntities, out.SafeSearch = existing.WebEntities, existing.SafeSearch
+		out.Place, out.Lat, out.Lng, out.Geohash, out.Country = existing.Place, existing.Lat, existing.Lng, existing.Geohash, existing.Country
+	case changeURLBase:
+		// only updating urlBase, preserve existing fields
+		out = *existing
+		out.URLBase = image.URLBase
+	default:
+		out = *existing
+	}
+
+	out.Resolutions = image.Resolutions
+	out.SetOrientations()
+	out.Appearances = mergeAppearances(history(*existing), image.Appearances)
+	out.Markets = appearanceMarkets(out.Appearances)
+	out.Localized = mergeLocalized(localizedHistory(*existing), image.Localized)
+	if out.Market == image.Market || out.Hsh == "" {
+		out.SetMetadata(image)
+	}
+	return out
+}
+
+// probeResolutions records which resolution variants of the image exist.
+func (u *Updater) probeResolutions(ctx context.Context, image *imgpkg.Image) error {
+	resolutions, err := u.prober.Probe(ctx, image.URLBase, imgpkg.Resolutions)
+	if err != nil {
+		return err
+	}
+	image.Resolutions = resolutions
+	image.SetOrientations()
+	return nil
+}
+
+// probeStored probes and saves the resolution variants of a stored image that has none recorded,
+// such as one stored before they were probed or no longer in Bing's feed.
+func (u *Updater) probeStored(ctx context.Context, image *imgpkg.Image) error {
+	if len(image.Resolutions) > 0 {
+		return nil
+	}
+
+	if err := u.probeResolutions(ctx, image); err != nil {
+		return fmt.Errorf("failed to probe %s: %w", image.ID, err)
+	}
+	return u.firestoreClient.UpdateResolutions(ctx, *image)
+}
+
+// newAnnotator configures the annotator from ANNOTATOR, using the Vision API by default.
+func newAnnotator(ctx context.Context) (annotate.Annotator, error) {
+	switch a := os.Getenv("ANNOTATOR"); a {
+	case "", "vision":
+		return annotate.NewVision(ctx)
+	case "local":
+		local := &annotate.Local{}
+		if path := os.Getenv("ANNOTATION_RULES"); path != "" {
+			rules, err := annotate.LoadRules(path)
 			if err != nil {
-				return err
-			} else if translatedTitle != "" {
-				image.Title = translatedTitle
+				return nil, err
 			}
+			local.Rules = rules
 		}
+		return local, nil
+	default:
+		return nil, fmt.Errorf("invalid annotator %q", a)
+	}
+}
+
+// newFetcher configures retries of HTTP requests from HTTP_MAX_RETRIES and the largest download from MAX_IMAGE_SIZE.
+func newFetcher() (*fetch.Fetcher, error) {
+	f := &fetch.Fetcher{
+		HC:        &http.Client{Timeout: time.Second * 15},
+		UserAgent: os.Getenv("USER_AGENT"),
+	}
 
-		anno, err := u.annotateImage(ctx, image.URL())
+	if v := os.Getenv("HTTP_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("invalid http max retries %q: %w", v, err)
+		}
+		if n == 0 {
+			n = -1
 		}
+		f.MaxRetries = n
+	}
 
-		// Process label annotations
-		for _, v := range anno.GetLabelAnnotations() {
-			image.Tags[strings.ToLower(v.Description)] = v.Score
+	if v := os.Getenv("MAX_IMAGE_SIZE"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid max image size %q", v)
 		}
+		f.MaxBodySize = n
+	}
 
-		// start: duplicate tags to t
-		tmp := make([]tag, 0, len(image.Tags))
-		for k, v := range image.Tags {
-			tmp = append(tmp, tag{Name: k, Score: v})
+	return f, nil
+}
+
+// newGeocoder configures the geocoder from GEOCODER, loading places from the GAZETTEER file if set.
+func newGeocoder() (geocode.Geocoder, error) {
+	switch g := os.Getenv("GEOCODER"); g {
+	case "", "gazetteer":
+		if path := os.Getenv("GAZETTEER"); path != "" {
+			return geocode.LoadGazetteer(path)
 		}
+		// landmarks still give a location, just not a country
+		return geocode.NewGazetteer(nil), nil
+	case "noop":
+		return geocode.Noop{}, nil
+	default:
+		return nil, fmt.Errorf("invalid geocoder %q", g)
+	}
+}
 
-		// sort by score
-		sort.SliceStable(tmp, func(i, j int) bool {
-			return tmp[i].Score > tmp[j].Score
-		})
+// newTranslator configures the translator from TRANSLATOR, caching Google translations per TRANSLATION_CACHE.
+func newTranslator(ctx context.Context, firestoreClient *firestore.Client) (translate.Translator, error) {
+	switch t := os.Getenv("TRANSLATOR"); t {
+	case "", "google":
+	case "dictionary":
+		return translate.LoadDictionary(os.Getenv("TRANSLATION_DICTIONARY"))
+	case "noop":
+		return translate.Noop{}, nil
+	default:
+		return nil, fmt.Errorf("invalid translator %q", t)
+	}
+
+	google, err := translate.NewGoogle(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cache := os.Getenv("TRANSLATION_CACHE"); cache {
+	case "off":
+		return google, nil
+	case "", "firestore":
+		return &translate.Cached{Translator: google, Cache: firestoreClient.TranslationCache(translationsCollection)}, nil
+	default:
+		return &translate.Cached{Translator: google, Cache: &translate.FileCache{Path: cache}}, nil
+	}
+}
+
+// acceptResolution applies the resolution policy to a new image.
+func (u *Updater) acceptResolution(image imgpkg.Image) bool {
+	if u.resolutionPolicy == ResolutionIgnore || slices.Contains(image.Resolutions, u.requiredResolution) {
+		return true
+	}
+
+	fmt.Printf("%s has no %s variant (found %s)\n", image.ID, u.requiredResolution, strings.Join(image.Resolutions, ", "))
+	return u.resolutionPolicy == ResolutionWarn
+}
 
-		image.TagsOrdered = make([]string, 0, len(tmp))
-		for _, v := range tmp {
-			image.TagsOrdered = append(image.TagsOrdered, strings.ReplaceAll(v.Name, " ", "-"))
+// translateNew translates the titles of new non-English images to English in a single batch.
+func (u *Updater) translateNew(ctx context.Context, translator translate.Translator, steps []step) error {
+	var idx []int
+	var titles []string
+	for i, s := range steps {
+		if s.change == changeNew && slices.Contains(nonENMarkets, s.doc.Market) {
+			idx = append(idx, i)
+			titles = append(titles, s.doc.Title)
 		}
-		// end: duplicate tags to t
+	}
+	if len(titles) == 0 {
+		return nil
+	}
 
-		// Extract up to 4 dominant colors as hex strings
-		if props := anno.GetImagePropertiesAnnotation(); props != nil {
-			if dc := props.GetDominantColors(); dc != nil {
-				colors := dc.GetColors()
-				for i := range min(4, len(colors)) {
-					c := colors[i].GetColor()
-					rgb := imgpkg.RGB{int(c.GetRed()), int(c.GetGreen()), int(c.GetBlue())}
-					image.Colors = append(image.Colors, rgb.ToHex())
-				}
-			}
+	translated, err := translator.Translate(ctx, titles, language.English)
+	if err != nil {
+		return err
+	}
+
+	for j, i := range idx {
+		// untranslated titles, e.g. missing from a dictionary, aren't English
+		if translated[j] == "" || translated[j] == titles[j] {
+			continue
 		}
 
-		_, err = u.firestoreClient.Upsert(ctx, image)
+		image := &steps[i].doc
+		image.Title = translated[j]
+		image.Localized = mergeLocalized(image.Localized, map[string]imgpkg.Localization{
+			"en": {Title: translated[j], Copyright: image.Copyright, MachineTranslated: true},
+		})
+	}
+	return nil
+}
+
+// locate sets the location and country of an image from its most confident landmark,
+// or else from the place named in its title.
+func (u *Updater) locate(ctx context.Context, image *imgpkg.Image) error {
+	image.Place, image.Lat, image.Lng, image.Geohash, image.Country = "", 0, 0, "", ""
+
+	for _, l := range image.Landmarks {
+		if l.Lat == 0 && l.Lng == 0 {
+			continue
+		}
+
+		image.Place, image.Lat, image.Lng = l.Name, l.Lat, l.Lng
+		place, err := u.geocoder.Reverse(ctx, l.Lat, l.Lng)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to reverse geocode %s: %w", image.ID, err)
+		}
+		if place != nil {
+			image.Country = place.Country
 		}
-		updatedImages = append(updatedImages, image.ID)
+		break
 	}
 
-	fmt.Printf("%d images updated: %s\n", len(updatedImages), strings.Join(updatedImages, ", "))
+	if image.Place == "" {
+		for _, name := range geocode.Candidates(image.Title) {
+			place, err := u.geocoder.Geocode(ctx, name)
+			if err != nil {
+				return fmt.Errorf("failed to geocode %s: %w", image.ID, err)
+			}
+			if place != nil {
+				image.Place, image.Lat, image.Lng, image.Country = place.Name, place.Lat, place.Lng, place.Country
+				break
+			}
+		}
+	}
+
+	if image.Place != "" {
+		image.Geohash = geo.Geohash(image.Lat, image.Lng, geo.Precision)
+	}
 	return nil
 }
 
-func (u *Updater) annotateImage(ctx context.Context, url string) (*visionpb.AnnotateImageResponse, error) {
-	// Download image first since Vision API can't access Bing URLs directly
-	imgReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// enrich annotates a new image, given its downloaded bytes.
+func (u *Updater) enrich(ctx context.Context, image *imgpkg.Image, imgBytes []byte) error {
+	anno, err := u.annotator.Annotate(ctx, imgBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create image request: %w", err)
+		return fmt.Errorf("failed to annotate %s: %w", image.URL(), err)
 	}
 
-	imgResp, err := u.httpClient.Do(imgReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to download image from %s: %w", url, err)
+	// Process label annotations
+	for _, v := range anno.Labels {
+		image.Tags[strings.ToLower(v.Name)] = v.Score
 	}
-	defer imgResp.Body.Close()
 
-	if imgResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to download image from %s: status %d", url, imgResp.StatusCode)
+	// start: duplicate tags to t
+	tmp := make([]tag, 0, len(image.Tags))
+	for k, v := range image.Tags {
+		tmp = append(tmp, tag{Name: k, Score: v})
 	}
 
-	imgBytes, err := io.ReadAll(imgResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read image from %s: %w", url, err)
-	}
-
-	req := &visionpb.BatchAnnotateImagesRequest{
-		Requests: []*visionpb.AnnotateImageRequest{
-			{
-				Image: &visionpb.Image{
-					Content: imgBytes,
-				},
-				Features: []*visionpb.Feature{
-					{
-						Type:       visionpb.Feature_LABEL_DETECTION,
-						MaxResults: 50,
-					},
-					{
-						Type:       visionpb.Feature_IMAGE_PROPERTIES,
-						MaxResults: 4,
-					},
-				},
-			},
-		},
-	}
-	images, err := u.annoClient.BatchAnnotateImages(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("vision API call failed: %w", err)
+	// sort by score
+	sort.SliceStable(tmp, func(i, j int) bool {
+		return tmp[i].Score > tmp[j].Score
+	})
+
+	image.TagsOrdered = make([]string, 0, len(tmp))
+	for _, v := range tmp {
+		image.TagsOrdered = append(image.TagsOrdered, strings.ReplaceAll(v.Name, " ", "-"))
 	}
+	// end: duplicate tags to t
 
-	responses := images.GetResponses()
-	if len(responses) == 0 {
-		return nil, fmt.Errorf("vision API returned no responses for %s", url)
+	// Extract up to 4 dominant colors as hex strings
+	for i := range min(4, len(anno.Colors)) {
+		image.Colors = append(image.Colors, anno.Colors[i].ToHex())
 	}
 
-	resp := responses[0]
-	if resp.GetError() != nil {
-		return nil, fmt.Errorf("vision API error for %s: %s", url, resp.GetError().GetMessage())
+	image.Landmarks = anno.Landmarks
+	image.LandmarkSlugs = nil
+	for _, l := range anno.Landmarks {
+		if slug := imgpkg.Slug(l.Name); slug != "" && !slices.Contains(image.LandmarkSlugs, slug) {
+			image.LandmarkSlugs = append(image.LandmarkSlugs, slug)
+		}
 	}
+	image.WebEntities = anno.WebEntities
+	image.SafeSearch = anno.SafeSearch
 
-	return resp, nil
+	return nil
 }
 
-func (u *Updater) fetchAndDedupeImages(ctx context.Context, markets []string, out map[string]imgpkg.Image) error {
+// download fetches an image, since the Vision API can't access Bing URLs directly.
+func (u *Updater) download(ctx context.Context, url string) ([]byte, error) {
+	imgBytes, err := u.fetcher.Get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+
+	return imgBytes, nil
+}
+
+// fetchAndDedupeImages adds the wallpapers Bing lists for markets to out, counting them per market in counts if it isn't nil.
+func (u *Updater) fetchAndDedupeImages(ctx context.Context, markets []string, out map[string]imgpkg.Image, counts map[string]int) error {
 	for _, market := range markets {
 		bi, err := u.imageClient.List(ctx, market)
 		if err != nil {
 			return err
 		}
 
-		for _, v := range bi {
-			if !v.WP {
-				continue
-			}
-
-			image, err := imgpkg.From(v, market)
-			if err != nil {
-				return err
-			}
-
-			if _, ok := out[image.ID]; !ok {
-				out[image.ID] = image
-			}
+		n, err := dedupeImages(bi, market, out)
+		if err != nil {
+			return err
+		}
+		if counts != nil {
+			counts[market] += n
 		}
 	}
 	return nil
 }
 
-func (u *Updater) translateText(ctx context.Context, text string) (string, error) {
-	lang, _ := language.Parse("en")
-	opts := &translate.Options{Format: "text"}
-	resp, err := u.translateClient.Translate(ctx, []string{text}, lang, opts)
-	if err != nil {
-		return "", fmt.Errorf("translate: %v", err)
-	}
-	if len(resp) == 0 {
-		return "", fmt.Errorf("translate returned empty response to text: %s", text)
-	}
+// dedupeImages adds the wallpapers Bing listed for a market to out, returning how many there were.
+func dedupeImages(bi []bing.Image, market string, out map[string]imgpkg.Image) (int, error) {
+	n := 0
+	for _, v := range bi {
+		if !v.WP {
+			continue
+		}
+		n++
+
+		image, err := imgpkg.From(v, market)
+		if err != nil {
+			return n, err
+		}
 
-	return resp[0].Text, nil
+		// keep the first market's image, but record every appearance
+		if existing, ok := out[image.ID]; ok {
+			existing.Appearances = mergeAppearances(existing.Appearances, image.Appearances)
+			existing.Markets = appearanceMarkets(existing.Appearances)
+			existing.Localized = mergeLocalized(existing.Localized, image.Localized)
+			out[image.ID] = existing
+		} else {
+			out[image.ID] = image
+		}
+	}
+	return n, nil
 }
 
 type tag struct {
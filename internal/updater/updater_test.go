@@ -0,0 +1,489 @@
+package updater
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/collections"
+	"api/internal/fetch"
+	"api/internal/updater/annotate"
+	"api/internal/updater/blob"
+	"api/internal/updater/firestore"
+	"api/internal/updater/geocode"
+	imgpkg "api/internal/updater/image"
+	"api/internal/updater/lock"
+	"api/internal/updater/message"
+	"api/internal/updater/translate"
+
+	gfirestore "cloud.google.com/go/firestore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	enUS := []imgpkg.Appearance{{Market: "en-US", Date: 20230101}}
+
+	tests := []struct {
+		name     string
+		existing *imgpkg.Image
+		image    imgpkg.Image
+		want     change
+	}{
+		{
+			name:  "New",
+			image: imgpkg.Image{ID: "a", Market: "en-US"},
+			want:  changeNew,
+		},
+		{
+			name:     "Unchanged",
+			existing: &imgpkg.Image{ID: "a", Title: "Title", Market: "en-US", URLBase: "x", Appearances: enUS, Localized: map[string]imgpkg.Localization{"en": {Title: "Title"}}},
+			image:    imgpkg.Image{ID: "a", Title: "Title", Market: "en-US", URLBase: "x", Appearances: enUS, Localized: map[string]imgpkg.Localization{"en": {Title: "Title"}}},
+			want:     changeNone,
+		},
+		{
+			name:     "MissingURLBase",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US"},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x"},
+			want:     changeURLBase,
+		},
+		{
+			name:     "MarketUpgrade",
+			existing: &imgpkg.Image{ID: "a", Market: "ja-JP", URLBase: "x"},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x"},
+			want:     changeMarket,
+		},
+		{
+			name:     "ResolutionsChanged",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Resolutions: []string{"1920x1080"}},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Resolutions: []string{"1920x1200", "1920x1080"}},
+			want:     changeResolutions,
+		},
+		{
+			name:     "NewAppearance",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS},
+			image:    imgpkg.Image{ID: "a", Market: "en-GB", URLBase: "x", Appearances: []imgpkg.Appearance{{Market: "en-GB", Date: 20230102}}},
+			want:     changeAppearances,
+		},
+		{
+			name:     "HistoryBackfilled",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", Date: 20230101, URLBase: "x"},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS},
+			want:     changeAppearances,
+		},
+		{
+			name:     "NewLanguage",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS, Localized: map[string]imgpkg.Localization{"en": {Title: "Title"}}},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS, Localized: map[string]imgpkg.Localization{"de": {Title: "Titel"}}},
+			want:     changeLocalized,
+		},
+		{
+			name:     "ContentChanged",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS, Hsh: "1"},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS, Hsh: "2"},
+			want:     changeContent,
+		},
+		{
+			name:     "OtherMarketCrop",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS, Hsh: "1"},
+			image:    imgpkg.Image{ID: "a", Market: "en-GB", URLBase: "x", Appearances: enUS, Hsh: "2"},
+			want:     changeNone,
+		},
+		{
+			name:     "MetadataMissing",
+			existing: &imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Appearances: enUS, Hsh: "1", Headline: "Happy Presidents Day!"},
+			want:     changeMetadata,
+		},
+		{
+			name:     "MarketUpgradeTakesPriority",
+			existing: &imgpkg.Image{ID: "a", Market: "ja-JP"},
+			image:    imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x"},
+			want:     changeMarket,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classify(tt.existing, tt.image))
+		})
+	}
+}
+
+func TestMergeAndDiff(t *testing.T) {
+	frFR := imgpkg.Appearance{Market: "fr-FR", Date: 20230101, Title: "Titre"}
+	enUS := imgpkg.Appearance{Market: "en-US", Date: 20230105, Title: "Title"}
+
+	localized := map[string]imgpkg.Localization{
+		"fr": {Title: "Titre"},
+		"en": {Title: "Translated title", MachineTranslated: true},
+	}
+	upgradedLocalized := map[string]imgpkg.Localization{
+		"fr": {Title: "Titre"},
+		"en": {Title: "Title"},
+	}
+
+	existing := imgpkg.Image{
+		ID: "a", Title: "Translated title", Date: 20230101, Market: "fr-FR",
+		Markets: []string{"fr-FR"}, Appearances: []imgpkg.Appearance{frFR}, Localized: localized, Colors: []string{"#000000"},
+		Mirrors:  map[string]imgpkg.Mirror{"UHD": {URL: "https://img.example.com/a/UHD.jpg"}},
+		Variants: map[string]imgpkg.Variant{"UHD": {Width: 3840, Height: 2160, AspectRatio: 1.778, Size: 1 << 20}},
+		BlurHash: "LfTI:j|cfQ|c|csUfQsUfQfQfQfQ", LQIP: "data:image/jpeg;base64,",
+	}
+	image := imgpkg.Image{
+		ID: "a", Title: "Title", Date: 20230105, Market: "en-US", URLBase: "x",
+		Markets: []string{"en-US"}, Appearances: []imgpkg.Appearance{enUS},
+		Localized: map[string]imgpkg.Localization{"en": {Title: "Title"}},
+	}
+
+	repaired := merge(&existing, image, changeURLBase)
+	assert.Equal(t, []FieldDiff{
+		{Field: "urlBase", Old: "", New: "x"},
+		{Field: "markets", Old: []string{"fr-FR"}, New: []string{"fr-FR", "en-US"}},
+		{Field: "appearances", Old: []imgpkg.Appearance{frFR}, New: []imgpkg.Appearance{frFR, enUS}},
+		{Field: "localized", Old: localized, New: upgradedLocalized},
+	}, diff(existing, repaired))
+
+	reprobed := merge(&existing, imgpkg.Image{Resolutions: []string{"UHD", "1080x1920"}}, changeResolutions)
+	assert.Equal(t, []FieldDiff{
+		{Field: "resolutions", Old: []string(nil), New: []string{"UHD", "1080x1920"}},
+		{Field: "landscape", Old: false, New: true},
+		{Field: "portrait", Old: false, New: true},
+	}, diff(existing, reprobed))
+
+	upgraded := merge(&existing, image, changeMarket)
+	assert.Equal(t, 20230101, upgraded.Date)
+	assert.Equal(t, []FieldDiff{
+		{Field: "title", Old: "Translated title", New: "Title"},
+		{Field: "market", Old: "fr-FR", New: "en-US"},
+		{Field: "urlBase", Old: "", New: "x"},
+		{Field: "markets", Old: []string{"fr-FR"}, New: []string{"fr-FR", "en-US"}},
+		{Field: "appearances", Old: []imgpkg.Appearance{frFR}, New: []imgpkg.Appearance{frFR, enUS}},
+		{Field: "localized", Old: localized, New: upgradedLocalized},
+	}, diff(existing, upgraded))
+
+	// an English market publishing the image keeps what Vision found in it
+	annotated := existing
+	annotated.Tags = map[string]float32{"monument": 0.9}
+	annotated.TagsOrdered = []string{"monument"}
+	annotated.Landmarks = []imgpkg.Landmark{{Name: "Lincoln Memorial", Score: 0.8, Lat: 38.8893, Lng: -77.0502}}
+	annotated.LandmarkSlugs = []string{"lincoln-memorial"}
+	annotated.SafeSearch = &imgpkg.SafeSearch{Adult: "VERY_UNLIKELY", Racy: "LIKELY"}
+	annotated.Place, annotated.Lat, annotated.Lng, annotated.Geohash, annotated.Country = "Lincoln Memorial", 38.8893, -77.0502, "dqcjq", "US"
+	upgraded = merge(&annotated, image, changeMarket)
+	assert.Equal(t, annotated.SafeSearch, upgraded.SafeSearch)
+	assert.Equal(t, annotated.Landmarks, upgraded.Landmarks)
+	assert.Equal(t, annotated.LandmarkSlugs, upgraded.LandmarkSlugs)
+	assert.Equal(t, annotated.Tags, upgraded.Tags)
+	assert.Equal(t, annotated.TagsOrdered, upgraded.TagsOrdered)
+	assert.Equal(t, annotated.Geohash, upgraded.Geohash)
+	assert.Equal(t, annotated.Country, upgraded.Country)
+}
+
+func TestMergeMetadata(t *testing.T) {
+	existing := imgpkg.Image{ID: "a", Market: "en-US", URLBase: "x", Headline: "Old", Hsh: "1"}
+
+	fromMarket := merge(&existing, imgpkg.Image{Market: "en-US", Headline: "New", Hsh: "2"}, changeContent)
+	assert.Equal(t, "New", fromMarket.Headline)
+	assert.Equal(t, "2", fromMarket.Hsh)
+
+	fromOtherMarket := merge(&existing, imgpkg.Image{Market: "en-GB", Headline: "Other", Hsh: "3"}, changeAppearances)
+	assert.Equal(t, "Old", fromOtherMarket.Headline)
+	assert.Equal(t, "1", fromOtherMarket.Hsh)
+}
+
+func TestMergeLocalizedHeadline(t *testing.T) {
+	assert.Equal(t, map[string]imgpkg.Localization{
+		"en": {Title: "Title", Headline: "Happy Presidents Day!"},
+		"fr": {Title: "Titre", MachineTranslated: true},
+	}, mergeLocalized(
+		map[string]imgpkg.Localization{"en": {Title: "Title"}, "fr": {Title: "Titre", MachineTranslated: true}},
+		map[string]imgpkg.Localization{"en": {Title: "Other title", Headline: "Happy Presidents Day!"}, "fr": {Title: "Titre 2", MachineTranslated: true}},
+	))
+}
+
+func TestLocalizedHistory(t *testing.T) {
+	assert.Equal(t, map[string]imgpkg.Localization{
+		"en": {Title: "Translated", Copyright: "Someone", MachineTranslated: true},
+	}, localizedHistory(imgpkg.Image{Title: "Translated", Copyright: "Someone", Market: "ja-JP"}))
+
+	assert.Equal(t, map[string]imgpkg.Localization{
+		"en": {Title: "Title"},
+	}, localizedHistory(imgpkg.Image{Title: "Title", Market: "en-GB"}))
+}
+
+func TestMergeDuplicate(t *testing.T) {
+	original := imgpkg.Image{ID: "a", Date: 20230105, Market: "en-US"}
+	image := imgpkg.Image{
+		ID: "b", Date: 20220301, Market: "ja-JP",
+		Appearances: []imgpkg.Appearance{{Market: "ja-JP", Date: 20220301, Title: "タイトル"}},
+	}
+
+	got := mergeDuplicate(original, image)
+	assert.Equal(t, "a", got.ID)
+	assert.Equal(t, 20220301, got.Date)
+	assert.Equal(t, []string{"ja-JP", "en-US"}, got.Markets)
+	assert.Equal(t, []imgpkg.Appearance{
+		{Market: "ja-JP", Date: 20220301, Title: "タイトル"},
+		{Market: "en-US", Date: 20230105},
+	}, got.Appearances)
+	assert.Equal(t, []string{"b"}, got.Aliases)
+
+	// merging again is a no-op
+	assert.Equal(t, got, mergeDuplicate(got, image))
+}
+
+func TestDuplicates_SameRun(t *testing.T) {
+	ctx := context.Background()
+	d := &duplicates{hashes: map[string]uint64{}, planned: map[string]imgpkg.Image{}, maxDistance: 6}
+
+	a := imgpkg.Image{ID: "a", Date: 20230105, Market: "en-US"}
+	d.add(a, 0b1111)
+
+	// found before it is stored
+	got, err := d.find(ctx, "b", 0b0111)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, a, *got)
+
+	merged := mergeDuplicate(*got, imgpkg.Image{ID: "b", Date: 20220301, Market: "ja-JP"})
+	d.merged(merged)
+	got, err = d.find(ctx, "c", 0b1111)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, got.Aliases)
+
+	steps := foldDuplicates([]step{
+		{id: "a", change: changeNew, doc: a},
+		{id: "x", change: changeMarket, doc: imgpkg.Image{ID: "x"}},
+		{id: "b", change: changeDuplicate, doc: merged},
+	})
+	assert.Equal(t, []step{
+		{id: "a", change: changeNew, doc: merged},
+		{id: "x", change: changeMarket, doc: imgpkg.Image{ID: "x"}},
+	}, steps)
+}
+
+func TestTranslateNew(t *testing.T) {
+	u := &Updater{translator: translate.Dictionary{"en": {"Titre": "Title"}}}
+
+	steps := []step{
+		{change: changeNew, doc: imgpkg.Image{Title: "Titre", Copyright: "Someone", Market: "fr-FR"}},
+		{change: changeNew, doc: imgpkg.Image{Title: "Title", Market: "en-US"}},
+		{change: changeMarket, doc: imgpkg.Image{Title: "Titre", Market: "fr-FR"}},
+		{change: changeNew, doc: imgpkg.Image{Title: "Inconnu", Market: "fr-FR"}},
+	}
+	require.NoError(t, u.translateNew(context.Background(), u.translator, steps))
+
+	assert.Equal(t, "Title", steps[0].doc.Title)
+	assert.Equal(t, map[string]imgpkg.Localization{
+		"en": {Title: "Title", Copyright: "Someone", MachineTranslated: true},
+	}, steps[0].doc.Localized)
+	assert.Equal(t, "Title", steps[1].doc.Title)
+	assert.Equal(t, "Titre", steps[2].doc.Title)
+	assert.Equal(t, "Inconnu", steps[3].doc.Title)
+	assert.Empty(t, steps[3].doc.Localized)
+}
+
+type fakeAnnotator struct {
+	anno *annotate.Annotation
+}
+
+func (f fakeAnnotator) Annotate(context.Context, []byte) (*annotate.Annotation, error) {
+	return f.anno, nil
+}
+
+func TestEnrich(t *testing.T) {
+	u := &Updater{annotator: fakeAnnotator{&annotate.Annotation{
+		Labels: []annotate.Label{{Name: "Sky", Score: 0.9}, {Name: "Body of water", Score: 0.95}},
+		Colors: []imgpkg.RGB{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11, 12}, {13, 14, 15}},
+		Landmarks: []imgpkg.Landmark{
+			{Name: "Washington Monument", Score: 0.8, Lat: 38.8895, Lng: -77.0353},
+			{Name: "Washington monument", Score: 0.4},
+		},
+	}}}
+
+	image := imgpkg.Image{ID: "a", Tags: make(map[string]float32)}
+	require.NoError(t, u.enrich(context.Background(), &image, nil))
+
+	assert.Equal(t, map[string]float32{"sky": 0.9, "body of water": 0.95}, image.Tags)
+	assert.Equal(t, []string{"body-of-water", "sky"}, image.TagsOrdered)
+	assert.Equal(t, []string{"#010203", "#040506", "#070809", "#0A0B0C"}, image.Colors)
+	assert.Len(t, image.Landmarks, 2)
+	assert.Equal(t, []string{"washington-monument"}, image.LandmarkSlugs)
+}
+
+func TestLocate(t *testing.T) {
+	u := &Updater{geocoder: geocode.NewGazetteer([]geocode.Place{
+		{Name: "Washington, DC", Lat: 38.9072, Lng: -77.0369, Country: "US"},
+		{Name: "Maui", Lat: 20.7984, Lng: -156.3319, Country: "US"},
+	})}
+	ctx := context.Background()
+
+	landmark := imgpkg.Image{
+		Title:     "Washington Monument and Capitol Building on the National Mall, Washington, DC",
+		Landmarks: []imgpkg.Landmark{{Name: "Washington Monument", Score: 0.8, Lat: 38.8895, Lng: -77.0353}},
+	}
+	require.NoError(t, u.locate(ctx, &landmark))
+	assert.Equal(t, "Washington Monument", landmark.Place)
+	assert.Equal(t, 38.8895, landmark.Lat)
+	assert.Equal(t, "US", landmark.Country)
+	assert.Equal(t, "dqcjpp8eg", landmark.Geohash)
+
+	title := imgpkg.Image{Title: "Humpback whales, Maui, Hawaii"}
+	require.NoError(t, u.locate(ctx, &title))
+	assert.Equal(t, "Maui", title.Place)
+	assert.Equal(t, "US", title.Country)
+
+	unknown := imgpkg.Image{Title: "Northern lights", Place: "Stale", Geohash: "u4pruydqq"}
+	require.NoError(t, u.locate(ctx, &unknown))
+	assert.Empty(t, unknown.Place)
+	assert.Empty(t, unknown.Geohash)
+}
+
+func TestMirror(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.RequestURI(), "_1920x1080.jpg") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("jpeg"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	u := &Updater{
+		blobs:   &blob.FS{Dir: dir, BaseURL: "https://img.example.com"},
+		fetcher: &fetch.Fetcher{HC: server.Client(), MaxRetries: -1},
+	}
+
+	image := imgpkg.Image{
+		ID:          "PresDayDC",
+		URLBase:     server.URL + "/th?id=OHR.PresDayDC_EN-US2054662773",
+		Resolutions: []string{"UHD", "1920x1200", "1920x1080"},
+		Mirrors:     map[string]imgpkg.Mirror{"UHD": {URL: "https://img.example.com/PresDayDC/UHD.jpg"}},
+	}
+	changed, err := u.mirror(context.Background(), &image)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	// UHD was already mirrored and 1920x1080 failed, so it is left to Bing
+	assert.Equal(t, map[string]imgpkg.Mirror{
+		"UHD": {URL: "https://img.example.com/PresDayDC/UHD.jpg"},
+		"1920x1200": {
+			URL:    "https://img.example.com/PresDayDC/1920x1200.jpg",
+			SHA256: "41e5787e9f28562d07b891b1816b492309d646c0f2829743fa4963a9f9cc1d61",
+			Size:   4,
+		},
+	}, image.Mirrors)
+
+	b, err := os.ReadFile(filepath.Join(dir, "PresDayDC", "1920x1200.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(b))
+
+	changed, err = u.mirror(context.Background(), &image)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestNewBlobStore_FSRequiresURL(t *testing.T) {
+	t.Setenv("MIRROR", "fs")
+	t.Setenv("MIRROR_DIR", t.TempDir())
+	t.Setenv("MIRROR_URL", "")
+	_, err := newBlobStore(context.Background())
+	assert.Error(t, err)
+
+	t.Setenv("MIRROR_URL", "https://img.example.com")
+	store, err := newBlobStore(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://img.example.com/PresDayDC/UHD.jpg", store.URL(mirrorKey("PresDayDC", "UHD")))
+}
+
+func TestOperations_RejectInvalid(t *testing.T) {
+	u := &Updater{}
+	ops := u.operations()
+
+	// rejected before anything is fetched
+	res := &Result{Markets: map[string]int{}}
+	err := ops[message.TypeUpdate](context.Background(), message.Message{Type: message.TypeUpdate, Markets: []string{"xx-XX"}}, res)
+	assert.ErrorIs(t, err, message.ErrInvalid)
+
+	err = ops[message.TypeBackfill](context.Background(), message.Message{Type: message.TypeBackfill, From: 20100101, To: 20100107}, res)
+	assert.ErrorIs(t, err, message.ErrInvalid)
+}
+
+func TestNewJobID(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 30, 5, 0, time.UTC)
+	a, b := newJobID(start), newJobID(start.Add(time.Second))
+	assert.True(t, strings.HasPrefix(a, "20250301T123005Z-"))
+	assert.Less(t, a, b)
+}
+
+func TestDaysBack(t *testing.T) {
+	today, err := strconv.Atoi(time.Now().UTC().Format("20060102"))
+	require.NoError(t, err)
+	yesterday, err := strconv.Atoi(time.Now().UTC().AddDate(0, 0, -1).Format("20060102"))
+	require.NoError(t, err)
+
+	days, err := daysBack(today)
+	require.NoError(t, err)
+	assert.Equal(t, 0, days)
+
+	days, err = daysBack(yesterday)
+	require.NoError(t, err)
+	assert.Equal(t, 1, days)
+}
+
+func TestLocked_Busy(t *testing.T) {
+	ctx := context.Background()
+	u := &Updater{locker: lock.NewMemory(), leaseTTL: time.Minute}
+
+	// another run holds the lease, so nothing is run or recorded
+	_, ok, err := u.locker.Acquire(ctx, updateLease, "", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	err = u.Locked(ctx, func(context.Context, *Updater) error {
+		t.Fatal("ran while another run held the lease")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrBusy)
+}
+
+func TestFenced_LostLease(t *testing.T) {
+	ctx := context.Background()
+	// nothing listens there, so a write that got past the fence would fail differently
+	t.Setenv("FIRESTORE_EMULATOR_HOST", "127.0.0.1:1")
+	fs, err := gfirestore.NewClient(ctx, "test")
+	require.NoError(t, err)
+	defer fs.Close()
+	c := firestore.NewClient("BingWallpapers", fs)
+	u := &Updater{
+		locker:          lock.NewMemory(),
+		leaseTTL:        time.Minute,
+		firestoreClient: c,
+		translator:      &translate.Cached{Translator: translate.Noop{}, Cache: c.TranslationCache(translationsCollection)},
+	}
+
+	h, ok, err := u.locker.Acquire(ctx, updateLease, "", time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, ok)
+	fenced := u.fenced(h)
+
+	// another run takes the lease over after it expires
+	time.Sleep(5 * time.Millisecond)
+	_, ok, err = u.locker.Acquire(ctx, updateLease, "", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// every write is refused before it reaches Firestore
+	assert.ErrorIs(t, fenced.firestoreClient.Upsert(ctx, imgpkg.Image{ID: "PresDayDC"}), lock.ErrLost)
+	assert.ErrorIs(t, fenced.firestoreClient.UpdateAnnotations(ctx, imgpkg.Image{ID: "PresDayDC"}), lock.ErrLost)
+	assert.ErrorIs(t, fenced.firestoreClient.AddPhotographer(ctx, collections.Photographers, imgpkg.Image{PhotographerSlug: "someone"}), lock.ErrLost)
+	cache := fenced.translator.(*translate.Cached).Cache
+	assert.ErrorIs(t, cache.Set(ctx, map[string]string{"fr:Titre": "Title"}), lock.ErrLost)
+}
@@ -151,6 +151,110 @@ func (_m *Storer) ListByTag(ctx context.Context, tag string, after float64) ([]s
 	return r0, r1, r2
 }
 
+// ListNear provides a mock function with given fields: ctx, lat, lng, radius, limit, safe
+func (_m *Storer) ListNear(ctx context.Context, lat float64, lng float64, radius float64, limit int, safe bool) ([]store.Wallpaper, error) {
+	ret := _m.Called(ctx, lat, lng, radius, limit, safe)
+
+	var r0 []store.Wallpaper
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, float64, float64, float64, int, bool) ([]store.Wallpaper, error)); ok {
+		return rf(ctx, lat, lng, radius, limit, safe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, float64, float64, float64, int, bool) []store.Wallpaper); ok {
+		r0 = rf(ctx, lat, lng, radius, limit, safe)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.Wallpaper)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, float64, float64, float64, int, bool) error); ok {
+		r1 = rf(ctx, lat, lng, radius, limit, safe)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, id
+func (_m *Storer) GetJob(ctx context.Context, id string) (*store.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *store.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*store.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *store.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobs provides a mock function with given fields: ctx, limit, startAfterID
+func (_m *Storer) ListJobs(ctx context.Context, limit int, startAfterID string) ([]store.Job, error) {
+	ret := _m.Called(ctx, limit, startAfterID)
+
+	var r0 []store.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]store.Job, error)); ok {
+		return rf(ctx, limit, startAfterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []store.Job); ok {
+		r0 = rf(ctx, limit, startAfterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, limit, startAfterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPhotographers provides a mock function with given fields: ctx, limit
+func (_m *Storer) ListPhotographers(ctx context.Context, limit int) ([]store.Photographer, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []store.Photographer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]store.Photographer, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []store.Photographer); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.Photographer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewStorer interface {
 	mock.TestingT
 	Cleanup(func())